@@ -1,55 +1,511 @@
 package collections
 
-type avlNode struct {
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"sync/atomic"
+)
+
+type avlNode[K, V any] struct {
 	h     int
-	value int
-	left  *avlNode
-	right *avlNode
+	n     int    // 子树元素数，多重集合模式下包含重复元素
+	c     int    // 当前键的元素数，非多重集合模式下恒为 1
+	gen   uint64 // 创建节点的树版本，只有版本相同的树可以原地修改该节点
+	key   K
+	value V
+	left  *avlNode[K, V]
+	right *avlNode[K, V]
+}
+
+// AVLTree 以比较函数决定键的顺序，存储键值对
+type AVLTree[K, V any] struct {
+	tree  *avlNode[K, V] // 根节点，空树为 nil
+	n     int            // 元素数量，多重集合模式下包含重复元素
+	cmp   func(a, b K) int
+	multi bool
+	gen   uint64
+
+	// 子树结构变化后刷新节点上的附加信息，如区间树中子树的最大端点
+	augment func(t *avlNode[K, V])
+}
+
+// 全局递增的树版本号
+var avlGen atomic.Uint64
+
+// RangeBound 表示 Range 区间是否包含边界，0 表示开区间 (lo, hi)
+type RangeBound uint8
+
+const (
+	IncludeLo   RangeBound              = 1 << iota // 包含下界 lo
+	IncludeHi                                       // 包含上界 hi
+	IncludeBoth = IncludeLo | IncludeHi             // 闭区间 [lo, hi]
+)
+
+// 生成 AVL 树，键类型需满足 cmp.Ordered
+func NewAVLTree[K cmp.Ordered, V any]() *AVLTree[K, V] {
+	return NewAVLTreeFunc[K, V](cmp.Compare[K])
+}
+
+// 使用自定义比较函数生成 AVL 树
+// cmp(a, b) 在 a < b 时返回负数，a == b 时返回 0，a > b 时返回正数
+func NewAVLTreeFunc[K, V any](cmp func(a, b K) int) *AVLTree[K, V] {
+	return &AVLTree[K, V]{cmp: cmp, gen: avlGen.Add(1)}
+}
+
+// 生成多重集合模式的 AVL 树，允许重复插入相同的键
+// 相同的键只存储一个节点和计数，所有重复元素共享同一个值，Put 已存在的键会覆盖该值
+func NewAVLMultiTree[K cmp.Ordered, V any]() *AVLTree[K, V] {
+	return NewAVLMultiTreeFunc[K, V](cmp.Compare[K])
 }
 
-type AVLTree struct {
-	tree *avlNode
+// 使用自定义比较函数生成多重集合模式的 AVL 树
+func NewAVLMultiTreeFunc[K, V any](cmp func(a, b K) int) *AVLTree[K, V] {
+	a := NewAVLTreeFunc[K, V](cmp)
+	a.multi = true
+	return a
 }
 
-// 生成 AVL 树
-func NewAVLTree() *AVLTree {
-	return &AVLTree{&avlNode{h: -2}}
+// 使用升序排列的键以 O(n) 构建完全平衡的 AVL 树，重复的键只保留一个
+func NewAVLTreeFromSorted[K cmp.Ordered, V any](keys []K) *AVLTree[K, V] {
+	a := NewAVLTree[K, V]()
+	a.loadSorted(keys, nil)
+	return a
 }
 
-// 插入节点
-func (a *AVLTree) Insert(v int) {
-	a.tree = insert(v, a.tree)
+// 使用升序排列的键和对应的值以 O(n) 构建完全平衡的 AVL 树，values[i] 为 keys[i] 的值
+// 重复的键只保留一个，值以最后出现的为准，keys 和 values 长度不同时 panic
+func NewAVLTreeFromSortedPairs[K cmp.Ordered, V any](keys []K, values []V) *AVLTree[K, V] {
+	checkPairs(keys, values)
+	a := NewAVLTree[K, V]()
+	a.loadSorted(keys, values)
+	return a
+}
+
+// 对无序的键先排序再构建完全平衡的树，替换树中原有的内容
+func (a *AVLTree[K, V]) BuildFrom(keys []K) {
+	sorted := make([]K, len(keys))
+	copy(sorted, keys)
+	MergeSortFunc(sorted, a.cmp)
+	a.loadSorted(sorted, nil)
+}
+
+// 对无序的键值对按键稳定排序后构建完全平衡的树，替换树中原有的内容
+// values[i] 为 keys[i] 的值，相同的键以最后出现的值为准，keys 和 values 长度不同时 panic
+func (a *AVLTree[K, V]) BuildFromPairs(keys []K, values []V) {
+	checkPairs(keys, values)
+	idx := make([]int, len(keys))
+	for i := range idx {
+		idx[i] = i
+	}
+	MergeSortFunc(idx, func(i, j int) int { return a.cmp(keys[i], keys[j]) })
+	sortedKeys, sortedValues := make([]K, len(keys)), make([]V, len(values))
+	for i, j := range idx {
+		sortedKeys[i], sortedValues[i] = keys[j], values[j]
+	}
+	a.loadSorted(sortedKeys, sortedValues)
+}
+
+func checkPairs[K, V any](keys []K, values []V) {
+	if len(keys) != len(values) {
+		panic("collections: keys and values must have the same length")
+	}
+}
+
+// 将升序排列的键合并相同项后一次性分配所有节点，再从中间节点开始递归连接
+// values 为 nil 时所有节点使用零值
+func (a *AVLTree[K, V]) loadSorted(keys []K, values []V) {
+	nodes := make([]avlNode[K, V], 0, len(keys))
+	for i, k := range keys {
+		if i > 0 {
+			cmp := a.cmp(keys[i-1], k)
+			if cmp > 0 {
+				panic("collections: keys are not sorted")
+			}
+			if cmp == 0 {
+				last := &nodes[len(nodes)-1]
+				if a.multi {
+					last.c++
+				}
+				if values != nil {
+					last.value = values[i]
+				}
+				continue
+			}
+		}
+		nodes = append(nodes, avlNode[K, V]{key: k, c: 1, gen: a.gen})
+		if values != nil {
+			nodes[len(nodes)-1].value = values[i]
+		}
+	}
+	a.setRoot(a.buildBalanced(nodes))
+}
+
+// 以中间节点为根递归构建完全平衡的子树
+func (a *AVLTree[K, V]) buildBalanced(nodes []avlNode[K, V]) *avlNode[K, V] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	t := &nodes[mid]
+	t.left = a.buildBalanced(nodes[:mid])
+	t.right = a.buildBalanced(nodes[mid+1:])
+	a.update(t)
+	return t
+}
+
+// 返回可以原地修改的节点，节点属于其他版本时复制一份，避免影响共享该节点的快照
+func (a *AVLTree[K, V]) mut(t *avlNode[K, V]) *avlNode[K, V] {
+	if t == nil || t.gen == a.gen {
+		return t
+	}
+	node := *t
+	node.gen = a.gen
+	return &node
+}
+
+// 替换根节点并同步元素数量
+func (a *AVLTree[K, V]) setRoot(t *avlNode[K, V]) {
+	a.tree = t
+	a.n = t.size()
+}
+
+// 插入或更新键值对，多重集合模式下键已存在时计数加一，并以 v 覆盖该键所有元素共享的值
+func (a *AVLTree[K, V]) Put(k K, v V) {
+	a.setRoot(a.insert(a.tree, k, v))
+}
+
+// 获取键对应的值
+func (a *AVLTree[K, V]) Get(k K) (V, bool) {
+	if t := a.search(a.tree, k); t != nil {
+		return t.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// 判断键是否存在
+func (a *AVLTree[K, V]) Contains(k K) bool {
+	return a.search(a.tree, k) != nil
+}
+
+// 插入节点，值为 V 的零值
+func (a *AVLTree[K, V]) Insert(k K) {
+	var zero V
+	a.Put(k, zero)
 }
 
 // 搜索节点
-func (a *AVLTree) Search(v int) bool {
-	return a.tree.search(v)
+func (a *AVLTree[K, V]) Search(k K) bool {
+	return a.Contains(k)
+}
+
+// 删除节点，多重集合模式下删除该键的所有元素
+func (a *AVLTree[K, V]) Delete(k K) bool {
+	return a.DeleteAll(k) > 0
 }
 
-// 删除节点
-func (a *AVLTree) Delete(v int) bool {
-	if a.tree.search(v) {
-		a.tree.delete(v)
+// 返回键 k 的元素数
+func (a *AVLTree[K, V]) Count(k K) int {
+	if t := a.search(a.tree, k); t != nil {
+		return t.c
+	}
+	return 0
+}
+
+// 删除键 k 的一个元素
+func (a *AVLTree[K, V]) DeleteOne(k K) bool {
+	if a.Contains(k) {
+		a.setRoot(a.delete(a.tree, k, true))
 		return true
 	}
 	return false
 }
 
-// 获取所有节点中的最大值
-func (a *AVLTree) GetMaxValue() int {
-	return a.tree.maxNode().value
+// 删除键 k 的所有元素，返回删除的元素数
+func (a *AVLTree[K, V]) DeleteAll(k K) int {
+	c := a.Count(k)
+	if c > 0 {
+		a.setRoot(a.delete(a.tree, k, false))
+	}
+	return c
+}
+
+// 获取所有节点中的最大键，空树返回 false
+func (a *AVLTree[K, V]) GetMaxValue() (K, bool) {
+	if t := a.tree.maxNode(); t != nil {
+		return t.key, true
+	}
+	var zero K
+	return zero, false
+}
+
+// 获取所有节点中的最小键，空树返回 false
+func (a *AVLTree[K, V]) GetMinValue() (K, bool) {
+	if t := a.tree.minNode(); t != nil {
+		return t.key, true
+	}
+	var zero K
+	return zero, false
+}
+
+// 弹出最小键的一个元素，与 PopMax 配合可作为双端优先队列使用
+func (a *AVLTree[K, V]) PopMin() (K, V, bool) {
+	return a.pop(a.tree.minNode())
 }
 
-// 获取所有节点中的最小值
-func (a *AVLTree) GetMinValue() int {
-	return a.tree.minNode().value
+// 弹出最大键的一个元素
+func (a *AVLTree[K, V]) PopMax() (K, V, bool) {
+	return a.pop(a.tree.maxNode())
 }
 
-// 返回排序后所有值
-func (a *AVLTree) AllValues() []int {
+func (a *AVLTree[K, V]) pop(t *avlNode[K, V]) (K, V, bool) {
+	if t == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	k, v := t.key, t.value
+	a.setRoot(a.delete(a.tree, k, true))
+	return k, v, true
+}
+
+// 返回排序后所有键，多重集合模式下包含重复的键
+func (a *AVLTree[K, V]) AllValues() []K {
+	return a.tree.keys()
+}
+
+// 按键的顺序返回所有值，多重集合模式下每个键的值重复该键的元素数次
+func (a *AVLTree[K, V]) Values() []V {
 	return a.tree.values()
 }
 
+// 元素数量，多重集合模式下包含重复元素
+func (a *AVLTree[K, V]) Len() int {
+	return a.n
+}
+
+// 返回小于 k 的键的数量
+func (a *AVLTree[K, V]) Rank(k K) int {
+	rank := 0
+	t := a.tree
+	for t != nil {
+		if a.cmp(k, t.key) > 0 {
+			// 左子树和当前节点都小于 k
+			rank += t.left.size() + t.c
+			t = t.right
+		} else {
+			t = t.left
+		}
+	}
+	return rank
+}
+
+// 返回第 i 小的键值对，i 从 0 开始
+func (a *AVLTree[K, V]) Select(i int) (K, V, bool) {
+	t := a.tree
+	if i < 0 || i >= t.size() {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for {
+		ls := t.left.size()
+		if i < ls {
+			t = t.left
+		} else if i >= ls+t.c {
+			// 跳过左子树和当前节点
+			i -= ls + t.c
+			t = t.right
+		} else {
+			return t.key, t.value, true
+		}
+	}
+}
+
+// 返回第 k 小的键值对，k 从 1 开始
+func (a *AVLTree[K, V]) Kth(k int) (K, V, bool) {
+	return a.Select(k - 1)
+}
+
+// 返回小于等于 k 的最大键值对
+func (a *AVLTree[K, V]) Floor(k K) (K, V, bool) {
+	return a.nearest(k, true, true)
+}
+
+// 返回大于等于 k 的最小键值对
+func (a *AVLTree[K, V]) Ceiling(k K) (K, V, bool) {
+	return a.nearest(k, false, true)
+}
+
+// 返回严格小于 k 的最大键值对
+func (a *AVLTree[K, V]) Lower(k K) (K, V, bool) {
+	return a.nearest(k, true, false)
+}
+
+// 返回严格大于 k 的最小键值对
+func (a *AVLTree[K, V]) Higher(k K) (K, V, bool) {
+	return a.nearest(k, false, false)
+}
+
+// 从根节点向下查找与 k 最接近的节点
+// less 为 true 时找 k 左侧的节点，否则找右侧的节点，equal 表示是否允许与 k 相等
+func (a *AVLTree[K, V]) nearest(k K, less, equal bool) (K, V, bool) {
+	var found *avlNode[K, V]
+	t := a.tree
+	for t != nil {
+		cmp := a.cmp(k, t.key)
+		if cmp == 0 && equal {
+			found = t
+			break
+		}
+		if less {
+			if cmp > 0 {
+				// 当前节点在 k 左侧，记录后继续往右逼近
+				found = t
+				t = t.right
+			} else {
+				t = t.left
+			}
+		} else {
+			if cmp < 0 {
+				// 当前节点在 k 右侧，记录后继续往左逼近
+				found = t
+				t = t.left
+			} else {
+				t = t.right
+			}
+		}
+	}
+	if found == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return found.key, found.value, true
+}
+
+// 按键升序遍历 [lo, hi] 区间内的键值对，bound 决定是否包含边界，fn 返回 false 时停止遍历
+func (a *AVLTree[K, V]) Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool) {
+	stack := make([]*avlNode[K, V], 0, a.tree.height()+1)
+	// 定位到第一个满足下界的节点，沿途记录需要回溯的节点
+	for t := a.tree; t != nil; {
+		cmp := a.cmp(t.key, lo)
+		if cmp > 0 || (cmp == 0 && bound&IncludeLo != 0) {
+			stack = append(stack, t)
+			t = t.left
+		} else {
+			t = t.right
+		}
+	}
+	for len(stack) > 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		cmp := a.cmp(t.key, hi)
+		if cmp > 0 || (cmp == 0 && bound&IncludeHi == 0) {
+			return
+		}
+		for i := 0; i < t.c; i++ {
+			if !fn(t.key, t.value) {
+				return
+			}
+		}
+		stack = pushLeft(stack, t.right)
+	}
+}
+
+// 返回按键升序遍历的迭代器
+func (a *AVLTree[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		root := a.tree
+		stack := pushLeft(make([]*avlNode[K, V], 0, root.height()+1), root)
+		for len(stack) > 0 {
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for i := 0; i < t.c; i++ {
+				if !yield(t.key, t.value) {
+					return
+				}
+			}
+			stack = pushLeft(stack, t.right)
+		}
+	}
+}
+
+// 返回按键降序遍历的迭代器
+func (a *AVLTree[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		root := a.tree
+		stack := pushRight(make([]*avlNode[K, V], 0, root.height()+1), root)
+		for len(stack) > 0 {
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for i := 0; i < t.c; i++ {
+				if !yield(t.key, t.value) {
+					return
+				}
+			}
+			stack = pushRight(stack, t.left)
+		}
+	}
+}
+
+// 将 t 及其左侧链上的节点依次入栈
+func pushLeft[K, V any](stack []*avlNode[K, V], t *avlNode[K, V]) []*avlNode[K, V] {
+	for ; t != nil; t = t.left {
+		stack = append(stack, t)
+	}
+	return stack
+}
+
+// 将 t 及其右侧链上的节点依次入栈
+func pushRight[K, V any](stack []*avlNode[K, V], t *avlNode[K, V]) []*avlNode[K, V] {
+	for ; t != nil; t = t.right {
+		stack = append(stack, t)
+	}
+	return stack
+}
+
+// 检查整棵树是否满足 BST 有序性、树高、元素数以及 AVL 平衡因子的约束
+func (a *AVLTree[K, V]) Validate() error {
+	_, err := a.validate(a.tree, nil, nil)
+	return err
+}
+
+// 校验以 t 为根的子树，lo 和 hi 为祖先节点限定的开区间边界，返回子树高度
+func (a *AVLTree[K, V]) validate(t, lo, hi *avlNode[K, V]) (int, error) {
+	if t == nil {
+		return -1, nil
+	}
+	if lo != nil && a.cmp(t.key, lo.key) <= 0 {
+		return 0, fmt.Errorf("collections: key %v is not greater than ancestor %v", t.key, lo.key)
+	}
+	if hi != nil && a.cmp(t.key, hi.key) >= 0 {
+		return 0, fmt.Errorf("collections: key %v is not less than ancestor %v", t.key, hi.key)
+	}
+	lh, err := a.validate(t.left, lo, t)
+	if err != nil {
+		return 0, err
+	}
+	rh, err := a.validate(t.right, t, hi)
+	if err != nil {
+		return 0, err
+	}
+	if h := max(lh, rh) + 1; t.h != h {
+		return 0, fmt.Errorf("collections: node %v stores height %d, want %d", t.key, t.h, h)
+	}
+	if lh-rh > 1 || rh-lh > 1 {
+		return 0, fmt.Errorf("collections: node %v has balance factor %d", t.key, lh-rh)
+	}
+	if t.c < 1 || (!a.multi && t.c != 1) {
+		return 0, fmt.Errorf("collections: node %v has count %d", t.key, t.c)
+	}
+	if n := t.left.size() + t.right.size() + t.c; t.n != n {
+		return 0, fmt.Errorf("collections: node %v stores size %d, want %d", t.key, t.n, n)
+	}
+	return t.h, nil
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -57,201 +513,262 @@ func max(a, b int) int {
 	return b
 }
 
-func insert(v int, t *avlNode) *avlNode {
+func (a *AVLTree[K, V]) insert(t *avlNode[K, V], k K, v V) *avlNode[K, V] {
 	if t == nil {
-		return &avlNode{value: v}
-	}
-	if t.h == -2 {
-		t.value = v
-		t.h = 0
+		t = &avlNode[K, V]{key: k, value: v, n: 1, c: 1, gen: a.gen}
+		a.update(t)
 		return t
 	}
+	t = a.mut(t)
 
-	cmp := v - t.value
+	cmp := a.cmp(k, t.key)
 	if cmp > 0 {
 		// 将节点插入到右子树中
-		t.right = insert(v, t.right)
+		t.right = a.insert(t.right, k, v)
 	} else if cmp < 0 {
 		// 将节点插入到左子树中
-		t.left = insert(v, t.left)
+		t.left = a.insert(t.left, k, v)
+	} else {
+		// 键已存在则更新值，多重集合模式下增加计数
+		t.value = v
+		if a.multi {
+			t.c++
+		}
+		a.update(t)
+		return t
 	}
 	// 维持树平衡
-	t = t.keepBalance(v)
-	t.h = max(t.left.height(), t.right.height()) + 1
-	return t
+	return a.keepBalance(t)
 }
 
-func (t *avlNode) search(v int) bool {
-	if t == nil {
-		return false
-	}
-	cmp := v - t.value
-	if cmp > 0 {
-		// 如果 v 大于当前节点值，继续从右子树中寻找
-		return t.right.search(v)
-	} else if cmp < 0 {
-		// 如果 v 小于当前节点值，继续从左子树中寻找
-		return t.left.search(v)
-	} else {
-		// 相等则表示找到
-		return true
+func (a *AVLTree[K, V]) search(t *avlNode[K, V], k K) *avlNode[K, V] {
+	for t != nil {
+		cmp := a.cmp(k, t.key)
+		if cmp > 0 {
+			// 如果 k 大于当前节点键，继续从右子树中寻找
+			t = t.right
+		} else if cmp < 0 {
+			// 如果 k 小于当前节点键，继续从左子树中寻找
+			t = t.left
+		} else {
+			// 相等则表示找到
+			return t
+		}
 	}
+	return nil
 }
 
-func (t *avlNode) delete(v int) *avlNode {
+// one 为 true 时只删除一个元素，计数归零后才删除节点
+func (a *AVLTree[K, V]) delete(t *avlNode[K, V], k K, one bool) *avlNode[K, V] {
 	if t == nil {
 		return t
 	}
-	cmp := v - t.value
+	t = a.mut(t)
+	cmp := a.cmp(k, t.key)
 	if cmp > 0 {
-		// 如果 v 大于当前节点值，继续从右子树中删除
-		t.right = t.right.delete(v)
+		// 如果 k 大于当前节点键，继续从右子树中删除
+		t.right = a.delete(t.right, k, one)
 	} else if cmp < 0 {
-		// 如果 v 小于当前节点值，继续从左子树中删除
-		t.left = t.left.delete(v)
+		// 如果 k 小于当前节点键，继续从左子树中删除
+		t.left = a.delete(t.left, k, one)
 	} else {
-		// 找到 v
+		// 找到 k
+		if one && t.c > 1 {
+			t.c--
+			a.update(t)
+			return t
+		}
 		if t.left != nil && t.right != nil {
 			// 如果该节点既有左子树又有右子树
 			// 使用右子树中的最小节点取代删除节点，然后删除右子树中的最小节点
-			t.value = t.right.minNode().value
-			t.right = t.right.delete(t.value)
+			min := t.right.minNode()
+			t.key, t.value, t.c = min.key, min.value, min.c
+			t.right = a.delete(t.right, t.key, false)
 		} else if t.left != nil {
 			// 如果只有左子树，则直接删除节点
-			t = t.left
+			return t.left
 		} else {
 			// 只有右子树或空树
-			t = t.right
+			return t.right
 		}
 	}
-
-	if t != nil {
-		t.h = max(t.left.height(), t.right.height()) + 1
-		t = t.keepBalance(v)
-	}
-	return t
+	return a.keepBalance(t)
 }
 
-func (t *avlNode) minNode() *avlNode {
+func (t *avlNode[K, V]) minNode() *avlNode[K, V] {
 	if t == nil {
 		return nil
 	}
-	// 整棵树的最左边节点就是值最小的节点
+	// 整棵树的最左边节点就是键最小的节点
 	if t.left == nil {
 		return t
-	} else {
-		return t.left.minNode()
 	}
+	return t.left.minNode()
 }
 
-func (t *avlNode) maxNode() *avlNode {
+func (t *avlNode[K, V]) maxNode() *avlNode[K, V] {
 	if t == nil {
 		return nil
 	}
-	// 整棵树的最右边节点就是值最大的节点
+	// 整棵树的最右边节点就是键最大的节点
 	if t.right == nil {
 		return t
-	} else {
-		return t.right.maxNode()
 	}
+	return t.right.maxNode()
 }
 
 /*
 左左情况：右旋
+
 		*
 	   *
 	  *
 */
-func (t *avlNode) llRotate() *avlNode {
+func (t *avlNode[K, V]) llRotate() *avlNode[K, V] {
 	node := t.left
 	t.left = node.right
 	node.right = t
 
-	node.h = max(node.left.height(), node.right.height()) + 1
-	t.h = max(t.left.height(), t.right.height()) + 1
+	t.update()
+	node.update()
 	return node
 }
 
 /*
 右右情况：左旋
+
 		*
 	     *
 	      *
 */
-func (t *avlNode) rrRotate() *avlNode {
+func (t *avlNode[K, V]) rrRotate() *avlNode[K, V] {
 	node := t.right
 	t.right = node.left
 	node.left = t
 
-	node.h = max(node.left.height(), node.right.height()) + 1
-	t.h = max(t.left.height(), t.right.height()) + 1
+	t.update()
+	node.update()
 	return node
 }
 
 /*
 左右情况：先左旋 后右旋
+
 		*
 	   *
 	    *
 */
-func (t *avlNode) lrRotate() *avlNode {
+func (t *avlNode[K, V]) lrRotate() *avlNode[K, V] {
 	t.left = t.left.rrRotate()
 	return t.llRotate()
 }
 
 /*
 右左情况：先右旋 后左旋
+
 		*
 	     *
-        *
+	    *
 */
-func (t *avlNode) rlRotate() *avlNode {
+func (t *avlNode[K, V]) rlRotate() *avlNode[K, V] {
 	t.right = t.right.llRotate()
 	return t.rrRotate()
 }
 
-func (t *avlNode) keepBalance(v int) *avlNode {
+// 根据左右子树的高度差旋转，插入和删除后都适用
+// t 需属于当前版本，旋转涉及的子节点在旋转前复制
+func (a *AVLTree[K, V]) keepBalance(t *avlNode[K, V]) *avlNode[K, V] {
+	root := t
 	// 左子树失衡
 	if t.left.height()-t.right.height() == 2 {
-		if v-t.left.value < 0 {
-			// 当插入的节点在失衡节点的左子树的左子树中，直接右旋
+		t.left = a.mut(t.left)
+		if t.left.left.height() >= t.left.right.height() {
+			// 失衡节点的左子树的左子树较高，直接右旋
 			t = t.llRotate()
 		} else {
-			// 当插入的节点在失衡节点的左子树的右子树中，先左旋后右旋
+			// 失衡节点的左子树的右子树较高，先左旋后右旋
+			t.left.right = a.mut(t.left.right)
 			t = t.lrRotate()
 		}
 	} else if t.right.height()-t.left.height() == 2 {
-		if t.right.right.height() > t.right.left.height() {
-			// 当插入的节点在失衡节点的右子树的右子树中，直接左旋
+		t.right = a.mut(t.right)
+		if t.right.right.height() >= t.right.left.height() {
+			// 失衡节点的右子树的右子树较高，直接左旋
 			t = t.rrRotate()
 		} else {
-			// 当插入的节点在失衡节点的右子树的左子树中，先右旋后左旋
+			// 失衡节点的右子树的左子树较高，先右旋后左旋
+			t.right.left = a.mut(t.right.left)
 			t = t.rlRotate()
 		}
 	}
+	if t != root {
+		// 旋转后新根节点的左右子节点都发生了变化
+		a.update(t.left)
+		a.update(t.right)
+	}
 	// 调整树高度
-	t.h = max(t.left.height(), t.right.height()) + 1
+	a.update(t)
 	return t
 }
 
-func (t *avlNode) height() int {
+func (t *avlNode[K, V]) height() int {
 	if t != nil {
 		return t.h
 	}
 	return -1
 }
 
+func (t *avlNode[K, V]) size() int {
+	if t != nil {
+		return t.n
+	}
+	return 0
+}
+
+// 根据左右子树重新计算树高和元素数
+func (t *avlNode[K, V]) update() {
+	t.h = max(t.left.height(), t.right.height()) + 1
+	t.n = t.left.size() + t.right.size() + t.c
+}
+
+// 重新计算树高和元素数，并刷新附加信息
+func (a *AVLTree[K, V]) update(t *avlNode[K, V]) {
+	t.update()
+	if a.augment != nil {
+		a.augment(t)
+	}
+}
+
+// 中序遍历按顺序获取所有键
+func appendKey[K, V any](keys []K, t *avlNode[K, V]) []K {
+	if t != nil {
+		keys = appendKey(keys, t.left)
+		for i := 0; i < t.c; i++ {
+			keys = append(keys, t.key)
+		}
+		keys = appendKey(keys, t.right)
+	}
+	return keys
+}
+
 // 中序遍历按顺序获取所有值
-func appendValue(values []int, t *avlNode) []int {
+func appendValue[K, V any](values []V, t *avlNode[K, V]) []V {
 	if t != nil {
 		values = appendValue(values, t.left)
-		values = append(values, t.value)
+		for i := 0; i < t.c; i++ {
+			values = append(values, t.value)
+		}
 		values = appendValue(values, t.right)
 	}
 	return values
 }
 
-func (t *avlNode) values() []int {
-	values := make([]int, 0)
+func (t *avlNode[K, V]) keys() []K {
+	keys := make([]K, 0)
+	return appendKey(keys, t)
+}
+
+func (t *avlNode[K, V]) values() []V {
+	values := make([]V, 0)
 	return appendValue(values, t)
 }
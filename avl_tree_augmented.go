@@ -0,0 +1,173 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Aggregator 定义子树聚合值的计算方式，聚合需满足结合律，Identity 为单位元
+// 如求和：Identity 为 0，Combine 返回 left + v + right
+type Aggregator[V, A any] struct {
+	Identity A                            // 空子树的聚合值
+	Combine  func(left A, v V, right A) A // 由左子树聚合值、当前节点的值和右子树聚合值计算子树聚合值
+}
+
+// 带有聚合值的节点值
+type aggregated[V, A any] struct {
+	v   V
+	agg A
+}
+
+// AugmentedAVLTree 每个节点额外维护子树聚合值的 AVL 树
+// 聚合值在插入、删除以及旋转后自底向上重新计算，可以 O(logn) 查询任意键区间的聚合值
+type AugmentedAVLTree[K, V, A any] struct {
+	tree *AVLTree[K, aggregated[V, A]]
+	agg  Aggregator[V, A]
+}
+
+// 生成带有子树聚合值的 AVL 树，键类型需满足 cmp.Ordered
+func NewAugmentedAVLTree[K cmp.Ordered, V, A any](agg Aggregator[V, A]) *AugmentedAVLTree[K, V, A] {
+	return NewAugmentedAVLTreeFunc[K, V, A](cmp.Compare[K], agg)
+}
+
+// 使用自定义比较函数生成带有子树聚合值的 AVL 树
+func NewAugmentedAVLTreeFunc[K, V, A any](cmp func(a, b K) int, agg Aggregator[V, A]) *AugmentedAVLTree[K, V, A] {
+	a := &AugmentedAVLTree[K, V, A]{tree: NewAVLTreeFunc[K, aggregated[V, A]](cmp), agg: agg}
+	a.tree.augment = func(t *avlNode[K, aggregated[V, A]]) {
+		t.value.agg = agg.Combine(a.aggOf(t.left), t.value.v, a.aggOf(t.right))
+	}
+	return a
+}
+
+// 插入或更新键值对
+func (a *AugmentedAVLTree[K, V, A]) Put(k K, v V) {
+	a.tree.Put(k, aggregated[V, A]{v: v})
+}
+
+// 获取键对应的值
+func (a *AugmentedAVLTree[K, V, A]) Get(k K) (V, bool) {
+	v, ok := a.tree.Get(k)
+	return v.v, ok
+}
+
+// 判断键是否存在
+func (a *AugmentedAVLTree[K, V, A]) Contains(k K) bool {
+	return a.tree.Contains(k)
+}
+
+// 删除节点
+func (a *AugmentedAVLTree[K, V, A]) Delete(k K) bool {
+	return a.tree.Delete(k)
+}
+
+// 节点数量
+func (a *AugmentedAVLTree[K, V, A]) Len() int {
+	return a.tree.Len()
+}
+
+// 返回按键升序遍历的迭代器
+func (a *AugmentedAVLTree[K, V, A]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range a.tree.Ascend() {
+			if !yield(k, v.v) {
+				return
+			}
+		}
+	}
+}
+
+// 按 k 将树切分为两棵树，left 中的键都小于 k，right 中的键都大于等于 k，两棵树都保留聚合值
+// 切分后原树为空
+func (a *AugmentedAVLTree[K, V, A]) Split(k K) (left, right *AugmentedAVLTree[K, V, A]) {
+	l, r := a.tree.Split(k)
+	return &AugmentedAVLTree[K, V, A]{tree: l, agg: a.agg}, &AugmentedAVLTree[K, V, A]{tree: r, agg: a.agg}
+}
+
+// 连接两棵树，要求 left 中的键都小于 right 中的键，否则 panic
+// 结果沿用 left 的聚合方式，连接后 left 和 right 均为空
+func JoinAugmentedAVLTree[K, V, A any](left, right *AugmentedAVLTree[K, V, A]) *AugmentedAVLTree[K, V, A] {
+	return &AugmentedAVLTree[K, V, A]{tree: JoinAVLTree(left.tree, right.tree), agg: left.agg}
+}
+
+// 并集，b 中的键值对合并到 a 中，相同的键以 b 的值为准，合并后 b 为空
+func (a *AugmentedAVLTree[K, V, A]) Union(b *AugmentedAVLTree[K, V, A]) {
+	a.tree.Union(b.tree)
+}
+
+// 交集，a 中只保留同时存在于 b 中的键，合并后 b 为空
+func (a *AugmentedAVLTree[K, V, A]) Intersection(b *AugmentedAVLTree[K, V, A]) {
+	a.tree.Intersection(b.tree)
+}
+
+// 差集，从 a 中删除存在于 b 中的键，合并后 b 为空
+func (a *AugmentedAVLTree[K, V, A]) Difference(b *AugmentedAVLTree[K, V, A]) {
+	a.tree.Difference(b.tree)
+}
+
+// 以 O(1) 复制整棵树，两棵树共享所有节点，之后任意一棵树的修改只复制涉及的路径
+func (a *AugmentedAVLTree[K, V, A]) Clone() *AugmentedAVLTree[K, V, A] {
+	return &AugmentedAVLTree[K, V, A]{tree: a.tree.Snapshot().Mutable(), agg: a.agg}
+}
+
+// 整棵树的聚合值
+func (a *AugmentedAVLTree[K, V, A]) Aggregate() A {
+	return a.aggOf(a.tree.tree)
+}
+
+// 返回键在 [lo, hi] 区间内的所有值按键升序的聚合值，bound 决定是否包含边界，时间复杂度 O(logn)
+func (a *AugmentedAVLTree[K, V, A]) RangeAggregate(lo, hi K, bound RangeBound) A {
+	// 找到第一个落在区间内的节点，区间内的其余节点都在它的左右子树中
+	t := a.tree.tree
+	for t != nil {
+		if !a.aboveLo(t.key, lo, bound) {
+			t = t.right
+		} else if !a.belowHi(t.key, hi, bound) {
+			t = t.left
+		} else {
+			break
+		}
+	}
+	if t == nil {
+		return a.agg.Identity
+	}
+	return a.agg.Combine(a.suffix(t.left, lo, bound), t.value.v, a.prefix(t.right, hi, bound))
+}
+
+// 子树中满足下界的节点的聚合值
+func (a *AugmentedAVLTree[K, V, A]) suffix(t *avlNode[K, aggregated[V, A]], lo K, bound RangeBound) A {
+	if t == nil {
+		return a.agg.Identity
+	}
+	if !a.aboveLo(t.key, lo, bound) {
+		return a.suffix(t.right, lo, bound)
+	}
+	return a.agg.Combine(a.suffix(t.left, lo, bound), t.value.v, a.aggOf(t.right))
+}
+
+// 子树中满足上界的节点的聚合值
+func (a *AugmentedAVLTree[K, V, A]) prefix(t *avlNode[K, aggregated[V, A]], hi K, bound RangeBound) A {
+	if t == nil {
+		return a.agg.Identity
+	}
+	if !a.belowHi(t.key, hi, bound) {
+		return a.prefix(t.left, hi, bound)
+	}
+	return a.agg.Combine(a.aggOf(t.left), t.value.v, a.prefix(t.right, hi, bound))
+}
+
+func (a *AugmentedAVLTree[K, V, A]) aboveLo(k, lo K, bound RangeBound) bool {
+	c := a.tree.cmp(k, lo)
+	return c > 0 || (c == 0 && bound&IncludeLo != 0)
+}
+
+func (a *AugmentedAVLTree[K, V, A]) belowHi(k, hi K, bound RangeBound) bool {
+	c := a.tree.cmp(k, hi)
+	return c < 0 || (c == 0 && bound&IncludeHi != 0)
+}
+
+func (a *AugmentedAVLTree[K, V, A]) aggOf(t *avlNode[K, aggregated[V, A]]) A {
+	if t == nil {
+		return a.agg.Identity
+	}
+	return t.value.agg
+}
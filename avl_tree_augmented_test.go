@@ -0,0 +1,129 @@
+package collections
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAugmentedAVLTreeSum(t *testing.T) {
+	tree := NewAugmentedAVLTree[int, int](Aggregator[int, int]{
+		Identity: 0,
+		Combine:  func(l, v, r int) int { return l + v + r },
+	})
+	oracle := make(map[int]int)
+	for i := 0; i < nums; i++ {
+		k, v := rand.Intn(nums), rand.Intn(100)
+		tree.Put(k, v)
+		oracle[k] = v
+		if i%3 == 0 {
+			d := rand.Intn(nums)
+			_, ok := oracle[d]
+			assert.Equal(t, ok, tree.Delete(d))
+			delete(oracle, d)
+		}
+	}
+	assert.Equal(t, len(oracle), tree.Len())
+
+	total := 0
+	for _, v := range oracle {
+		total += v
+	}
+	assert.Equal(t, total, tree.Aggregate())
+
+	for i := 0; i < maxNum; i++ {
+		lo := rand.Intn(nums)
+		hi := lo + rand.Intn(nums/4)
+		for _, bound := range []RangeBound{0, IncludeLo, IncludeHi, IncludeBoth} {
+			want := 0
+			for k, v := range oracle {
+				if (k > lo || (k == lo && bound&IncludeLo != 0)) && (k < hi || (k == hi && bound&IncludeHi != 0)) {
+					want += v
+				}
+			}
+			assert.Equal(t, want, tree.RangeAggregate(lo, hi, bound))
+		}
+	}
+	assert.Equal(t, 0, tree.RangeAggregate(10, 5, IncludeBoth))
+}
+
+func TestAugmentedAVLTreeMinField(t *testing.T) {
+	type order struct {
+		Price float64
+		Qty   int
+	}
+	type stats struct {
+		MinPrice float64
+		Qty      int
+	}
+	tree := NewAugmentedAVLTree[int, order](Aggregator[order, stats]{
+		Identity: stats{MinPrice: math.Inf(1)},
+		Combine: func(l stats, v order, r stats) stats {
+			return stats{MinPrice: math.Min(math.Min(l.MinPrice, v.Price), r.MinPrice), Qty: l.Qty + v.Qty + r.Qty}
+		},
+	})
+	for i := 1; i <= 10; i++ {
+		tree.Put(i, order{Price: float64(100 - i), Qty: i})
+	}
+	assert.Equal(t, stats{MinPrice: 90, Qty: 55}, tree.Aggregate())
+	assert.Equal(t, stats{MinPrice: 95, Qty: 2 + 3 + 4 + 5}, tree.RangeAggregate(2, 5, IncludeBoth))
+	tree.Put(3, order{Price: 1, Qty: 0})
+	assert.Equal(t, stats{MinPrice: 1, Qty: 2 + 4 + 5}, tree.RangeAggregate(2, 5, IncludeBoth))
+	tree.Delete(3)
+	assert.Equal(t, stats{MinPrice: 90, Qty: 52}, tree.Aggregate())
+	v, ok := tree.Get(4)
+	assert.True(t, ok)
+	assert.Equal(t, order{Price: 96, Qty: 4}, v)
+	n := 0
+	for range tree.Ascend() {
+		n++
+	}
+	assert.Equal(t, 9, n)
+}
+
+func TestAugmentedAVLTreeSplitJoin(t *testing.T) {
+	sum := Aggregator[int, int]{
+		Identity: 0,
+		Combine:  func(l, v, r int) int { return l + v + r },
+	}
+	// 键 i 的值为 i，区间和可以直接计算
+	gen := func(lo, hi int) *AugmentedAVLTree[int, int, int] {
+		tree := NewAugmentedAVLTree[int, int](sum)
+		for i := lo; i < hi; i++ {
+			tree.Put(i, i)
+		}
+		return tree
+	}
+	total := func(lo, hi int) int { return (lo + hi - 1) * (hi - lo) / 2 }
+
+	tree := gen(0, nums)
+	left, right := tree.Split(nums / 3)
+	assert.Equal(t, 0, tree.Len())
+	assert.Equal(t, total(0, nums/3), left.Aggregate())
+	assert.Equal(t, total(nums/3, nums), right.Aggregate())
+	assert.Equal(t, total(nums/3, nums/2), right.RangeAggregate(0, nums/2, IncludeLo))
+
+	// 连接时聚合值沿连接路径重新计算
+	right.Put(nums, 1)
+	tree = JoinAugmentedAVLTree(left, right)
+	assert.Equal(t, total(0, nums)+1, tree.Aggregate())
+	assert.Equal(t, total(10, 20), tree.RangeAggregate(10, 20, IncludeLo))
+
+	// 复制后两棵树的修改互不影响，聚合值各自正确
+	clone := tree.Clone()
+	clone.Delete(nums)
+	tree.Put(0, 100)
+	assert.Equal(t, total(0, nums), clone.Aggregate())
+	assert.Equal(t, total(0, nums)+101, tree.Aggregate())
+
+	other := gen(nums/2, nums+nums/2)
+	clone.Union(other)
+	assert.Equal(t, total(0, nums+nums/2), clone.Aggregate())
+	clone.Intersection(gen(nums/4, nums))
+	assert.Equal(t, total(nums/4, nums), clone.Aggregate())
+	clone.Difference(gen(nums/2, nums))
+	assert.Equal(t, total(nums/4, nums/2), clone.Aggregate())
+	assert.Equal(t, nums/2-nums/4, clone.Len())
+}
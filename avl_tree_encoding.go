@@ -0,0 +1,174 @@
+package collections
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+const (
+	avlMagic   = "AVLT"
+	avlVersion = 1
+	avlMulti   = 1 << 0
+)
+
+var (
+	errAVLNoCmp   = errors.New("collections: AVLTree has no comparator, create it with NewAVLTree or NewAVLTreeFunc")
+	errAVLInvalid = errors.New("collections: invalid AVLTree data")
+	errAVLMode    = errors.New("collections: cannot load set data into a multiset AVLTree or vice versa")
+)
+
+// 按键升序排列的键值对，序列化时使用
+type avlEntries[K, V any] struct {
+	Keys   []K
+	Values []V
+	Counts []int // 仅多重集合模式下写入
+}
+
+type avlJSONEntry[K, V any] struct {
+	Key   K   `json:"k"`
+	Value V   `json:"v"`
+	Count int `json:"n,omitempty"`
+}
+
+type avlJSON[K, V any] struct {
+	Version int                  `json:"version"`
+	Multi   bool                 `json:"multi,omitempty"`
+	Entries []avlJSONEntry[K, V] `json:"entries"`
+}
+
+// 实现 encoding.BinaryMarshaler
+// 格式为 4 字节魔数、1 字节版本号、1 字节标志位，之后是 gob 编码的升序键值对
+// 值类型大小为 0（如 struct{}）时不写入值
+func (a *AVLTree[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(avlMagic)
+	var flags byte
+	if a.multi {
+		flags |= avlMulti
+	}
+	buf.WriteByte(avlVersion)
+	buf.WriteByte(flags)
+	if err := gob.NewEncoder(&buf).Encode(a.entries(reflect.TypeFor[V]().Size() > 0)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// 实现 encoding.BinaryUnmarshaler，以 O(n) 重建完全平衡的树并替换原有内容
+// 树需由 NewAVLTree 等构造函数创建以提供比较函数，数据的模式与树不同时返回错误
+func (a *AVLTree[K, V]) UnmarshalBinary(data []byte) error {
+	if len(data) < len(avlMagic)+2 || string(data[:len(avlMagic)]) != avlMagic {
+		return errAVLInvalid
+	}
+	header := data[len(avlMagic):]
+	if header[0] != avlVersion {
+		return fmt.Errorf("collections: unsupported AVLTree binary version %d", header[0])
+	}
+	var e avlEntries[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(header[2:])).Decode(&e); err != nil {
+		return err
+	}
+	return a.load(e, header[1]&avlMulti != 0)
+}
+
+// 实现 json.Marshaler，按键升序输出 {"version":1,"entries":[{"k":...,"v":...}]}
+// 多重集合模式下带有 "multi":true，计数大于 1 的键带有 "n"
+func (a *AVLTree[K, V]) MarshalJSON() ([]byte, error) {
+	e := a.entries(true)
+	out := avlJSON[K, V]{Version: avlVersion, Multi: a.multi, Entries: make([]avlJSONEntry[K, V], len(e.Keys))}
+	for i := range e.Keys {
+		out.Entries[i] = avlJSONEntry[K, V]{Key: e.Keys[i], Value: e.Values[i]}
+		if a.multi && e.Counts[i] > 1 {
+			out.Entries[i].Count = e.Counts[i]
+		}
+	}
+	return json.Marshal(out)
+}
+
+// 实现 json.Unmarshaler，以 O(n) 重建完全平衡的树并替换原有内容，JSON null 不做任何修改
+// 树需由 NewAVLTree 等构造函数创建以提供比较函数，数据的模式与树不同时返回错误
+func (a *AVLTree[K, V]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	var in avlJSON[K, V]
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if in.Version != avlVersion {
+		return fmt.Errorf("collections: unsupported AVLTree json version %d", in.Version)
+	}
+	e := avlEntries[K, V]{
+		Keys:   make([]K, len(in.Entries)),
+		Values: make([]V, len(in.Entries)),
+	}
+	if in.Multi {
+		e.Counts = make([]int, len(in.Entries))
+	}
+	for i, entry := range in.Entries {
+		e.Keys[i], e.Values[i] = entry.Key, entry.Value
+		if in.Multi {
+			e.Counts[i] = max(entry.Count, 1)
+		}
+	}
+	return a.load(e, in.Multi)
+}
+
+// 按键升序导出所有键值对，withValues 为 false 时不导出值
+func (a *AVLTree[K, V]) entries(withValues bool) avlEntries[K, V] {
+	n := a.tree.size()
+	e := avlEntries[K, V]{Keys: make([]K, 0, n)}
+	if withValues {
+		e.Values = make([]V, 0, n)
+	}
+	if a.multi {
+		e.Counts = make([]int, 0, n)
+	}
+	for stack := pushLeft(nil, a.tree); len(stack) > 0; {
+		t := stack[len(stack)-1]
+		stack = pushLeft(stack[:len(stack)-1], t.right)
+		e.Keys = append(e.Keys, t.key)
+		if withValues {
+			e.Values = append(e.Values, t.value)
+		}
+		if a.multi {
+			e.Counts = append(e.Counts, t.c)
+		}
+	}
+	return e
+}
+
+// 校验键严格升序后重建完全平衡的树，树的模式保持不变
+func (a *AVLTree[K, V]) load(e avlEntries[K, V], multi bool) error {
+	if a.cmp == nil {
+		return errAVLNoCmp
+	}
+	if multi != a.multi {
+		return errAVLMode
+	}
+	if (e.Values != nil && len(e.Values) != len(e.Keys)) || (multi && len(e.Counts) != len(e.Keys)) {
+		return errAVLInvalid
+	}
+	nodes := make([]avlNode[K, V], len(e.Keys))
+	for i, k := range e.Keys {
+		if i > 0 && a.cmp(e.Keys[i-1], k) >= 0 {
+			return fmt.Errorf("collections: AVLTree keys are not strictly ascending at index %d", i)
+		}
+		nodes[i] = avlNode[K, V]{key: k, c: 1, gen: a.gen}
+		if e.Values != nil {
+			nodes[i].value = e.Values[i]
+		}
+		if multi {
+			if e.Counts[i] < 1 {
+				return fmt.Errorf("collections: AVLTree count %d at index %d is not positive", e.Counts[i], i)
+			}
+			nodes[i].c = e.Counts[i]
+		}
+	}
+	a.setRoot(a.buildBalanced(nodes))
+	return nil
+}
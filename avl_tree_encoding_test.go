@@ -0,0 +1,88 @@
+package collections
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAVLTreeMarshalBinary(t *testing.T) {
+	tree := NewAVLTree[int, string]()
+	for i := 0; i < maxNum; i++ {
+		k := rand.Intn(maxNum * 10)
+		tree.Put(k, string(rune('a'+k%26)))
+	}
+	data, err := tree.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, "AVLT", string(data[:4]))
+
+	loaded := NewAVLTree[int, string]()
+	loaded.Insert(-1)
+	assert.Nil(t, loaded.UnmarshalBinary(data))
+	assert.Nil(t, loaded.Validate())
+	assert.Equal(t, tree.AllValues(), loaded.AllValues())
+	assert.Equal(t, tree.Values(), loaded.Values())
+
+	set := NewAVLMultiTree[string, struct{}]()
+	for _, k := range []string{"b", "a", "b", "c", "b"} {
+		set.Insert(k)
+	}
+	data, err = set.MarshalBinary()
+	assert.Nil(t, err)
+	// 数据与树的模式不同时返回错误，树保持不变
+	assert.Equal(t, errAVLMode, NewAVLTree[string, struct{}]().UnmarshalBinary(data))
+	loadedSet := NewAVLMultiTree[string, struct{}]()
+	assert.Nil(t, loadedSet.UnmarshalBinary(data))
+	assert.Nil(t, loadedSet.Validate())
+	assert.Equal(t, []string{"a", "b", "b", "b", "c"}, loadedSet.AllValues())
+	assert.Equal(t, 3, loadedSet.Count("b"))
+
+	assert.NotNil(t, loaded.UnmarshalBinary([]byte("nope")))
+	bad := append([]byte{}, data...)
+	bad[4] = 99
+	assert.NotNil(t, loadedSet.UnmarshalBinary(bad))
+	var zero AVLTree[string, struct{}]
+	assert.Equal(t, errAVLNoCmp, zero.UnmarshalBinary(data))
+}
+
+func TestAVLTreeMarshalJSON(t *testing.T) {
+	tree := NewAVLTree[string, int]()
+	tree.Put("b", 2)
+	tree.Put("a", 1)
+	tree.Put("c", 3)
+	data, err := json.Marshal(tree)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"version":1,"entries":[{"k":"a","v":1},{"k":"b","v":2},{"k":"c","v":3}]}`, string(data))
+
+	loaded := NewAVLTree[string, int]()
+	assert.Nil(t, json.Unmarshal(data, loaded))
+	assert.Nil(t, loaded.Validate())
+	assert.Equal(t, []string{"a", "b", "c"}, loaded.AllValues())
+	assert.Equal(t, []int{1, 2, 3}, loaded.Values())
+
+	multi := NewAVLMultiTree[int, struct{}]()
+	multi.BuildFrom([]int{2, 1, 2})
+	data, err = json.Marshal(multi)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"version":1,"multi":true,"entries":[{"k":1,"v":{}},{"k":2,"v":{},"n":2}]}`, string(data))
+	loadedMulti := NewAVLMultiTree[int, struct{}]()
+	assert.Nil(t, json.Unmarshal(data, loadedMulti))
+	assert.Equal(t, []int{1, 2, 2}, loadedMulti.AllValues())
+
+	// 多重集合模式的树加载集合数据时返回错误，之后仍然是多重集合
+	assert.NotNil(t, json.Unmarshal([]byte(`{"version":1,"entries":[{"k":3,"v":{}}]}`), loadedMulti))
+	loadedMulti.Insert(1)
+	assert.Equal(t, 2, loadedMulti.Count(1))
+	assert.NotNil(t, json.Unmarshal(data, NewAVLTree[int, struct{}]()))
+
+	// null 不做任何修改
+	assert.Nil(t, loadedMulti.UnmarshalJSON([]byte("null")))
+	assert.Equal(t, []int{1, 1, 2, 2}, loadedMulti.AllValues())
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"version":2,"entries":[]}`), loaded))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"version":1,"entries":[{"k":"b"},{"k":"a"}]}`), loaded))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"version":1,"entries":[{"k":"a"},{"k":"a"}]}`), loaded))
+	assert.Equal(t, []string{"a", "b", "c"}, loaded.AllValues())
+}
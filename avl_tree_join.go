@@ -0,0 +1,207 @@
+package collections
+
+// 按 k 将树切分为两棵树，left 中的键都小于 k，right 中的键都大于等于 k
+// 切分后原树为空
+func (a *AVLTree[K, V]) Split(k K) (left, right *AVLTree[K, V]) {
+	l, m, r := a.split(a.tree, k)
+	if m != nil {
+		r = a.joinNode(nil, m, r)
+	}
+	left, right = a.empty(), a.empty()
+	left.setRoot(l)
+	right.setRoot(r)
+	a.setRoot(nil)
+	return left, right
+}
+
+// 连接两棵树，要求 left 中的键都小于 right 中的键，否则 panic
+// 两棵树需使用相同的比较函数和模式，模式不同时 panic，连接后 left 和 right 均为空
+func JoinAVLTree[K, V any](left, right *AVLTree[K, V]) *AVLTree[K, V] {
+	checkAVLMode(left.multi, right.multi)
+	l, r := left.tree, right.tree
+	if l != nil && r != nil && left.cmp(l.maxNode().key, r.minNode().key) >= 0 {
+		panic("collections: keys of left tree must be less than keys of right tree")
+	}
+	res := left.empty()
+	res.setRoot(res.join2(l, r))
+	left.setRoot(nil)
+	right.setRoot(nil)
+	return res
+}
+
+// 并集，b 中的键值对合并到 a 中，相同的键以 b 的值为准，多重集合模式下计数相加
+// 两棵树需使用相同的比较函数和模式，模式不同时 panic，合并后 b 为空，b 为 a 自身时不做任何操作
+func (a *AVLTree[K, V]) Union(b *AVLTree[K, V]) {
+	checkAVLMode(a.multi, b.multi)
+	if a == b {
+		return
+	}
+	a.setRoot(a.union(a.tree, b.tree))
+	b.setRoot(nil)
+}
+
+// 交集，a 中只保留同时存在于 b 中的键，多重集合模式下计数取较小值
+// 两棵树需使用相同的比较函数和模式，模式不同时 panic，求交集后 b 为空，b 为 a 自身时不做任何操作
+func (a *AVLTree[K, V]) Intersection(b *AVLTree[K, V]) {
+	checkAVLMode(a.multi, b.multi)
+	if a == b {
+		return
+	}
+	a.setRoot(a.intersection(a.tree, b.tree))
+	b.setRoot(nil)
+}
+
+// 差集，从 a 中移除存在于 b 中的键，多重集合模式下计数相减
+// 两棵树需使用相同的比较函数和模式，模式不同时 panic，求差集后 b 为空，b 为 a 自身时 a 被清空
+func (a *AVLTree[K, V]) Difference(b *AVLTree[K, V]) {
+	checkAVLMode(a.multi, b.multi)
+	if a == b {
+		a.setRoot(nil)
+		return
+	}
+	a.setRoot(a.difference(a.tree, b.tree))
+	b.setRoot(nil)
+}
+
+// 集合与多重集合的计数规则不同，两棵树模式不同时 panic
+func checkAVLMode(multi1, multi2 bool) {
+	if multi1 != multi2 {
+		panic("collections: cannot combine a set tree with a multiset tree")
+	}
+}
+
+// 生成与 a 比较函数和模式相同的空树
+func (a *AVLTree[K, V]) empty() *AVLTree[K, V] {
+	return &AVLTree[K, V]{cmp: a.cmp, multi: a.multi, gen: avlGen.Add(1), augment: a.augment}
+}
+
+// 按 k 切分子树，返回小于 k 的部分、键等于 k 的节点以及大于 k 的部分
+func (a *AVLTree[K, V]) split(t *avlNode[K, V], k K) (l, m, r *avlNode[K, V]) {
+	if t == nil {
+		return nil, nil, nil
+	}
+	t = a.mut(t)
+	cmp := a.cmp(k, t.key)
+	if cmp < 0 {
+		l, m, r = a.split(t.left, k)
+		return l, m, a.joinNode(r, t, t.right)
+	} else if cmp > 0 {
+		l, m, r = a.split(t.right, k)
+		return a.joinNode(t.left, t, l), m, r
+	}
+	l, r = t.left, t.right
+	t.left, t.right = nil, nil
+	a.update(t)
+	return l, t, r
+}
+
+func (a *AVLTree[K, V]) union(t1, t2 *avlNode[K, V]) *avlNode[K, V] {
+	if t1 == nil {
+		return t2
+	}
+	if t2 == nil {
+		return t1
+	}
+	t1 = a.mut(t1)
+	l2, m, r2 := a.split(t2, t1.key)
+	l := a.union(t1.left, l2)
+	r := a.union(t1.right, r2)
+	if m != nil {
+		t1.value = m.value
+		if a.multi {
+			t1.c += m.c
+		}
+	}
+	return a.joinNode(l, t1, r)
+}
+
+func (a *AVLTree[K, V]) intersection(t1, t2 *avlNode[K, V]) *avlNode[K, V] {
+	if t1 == nil || t2 == nil {
+		return nil
+	}
+	t1 = a.mut(t1)
+	l2, m, r2 := a.split(t2, t1.key)
+	l := a.intersection(t1.left, l2)
+	r := a.intersection(t1.right, r2)
+	if m == nil {
+		return a.join2(l, r)
+	}
+	t1.c = min(t1.c, m.c)
+	return a.joinNode(l, t1, r)
+}
+
+func (a *AVLTree[K, V]) difference(t1, t2 *avlNode[K, V]) *avlNode[K, V] {
+	if t1 == nil || t2 == nil {
+		return t1
+	}
+	l1, m, r1 := a.split(t1, t2.key)
+	l := a.difference(l1, t2.left)
+	r := a.difference(r1, t2.right)
+	if m == nil || !a.multi || m.c <= t2.c {
+		return a.join2(l, r)
+	}
+	m.c -= t2.c
+	return a.joinNode(l, m, r)
+}
+
+// 以 m 为中间节点连接 l 和 r，要求 l 中的键都小于 m 的键，r 中的键都大于 m 的键，m 需属于当前版本
+// 从较高的一棵树沿边界向下找到高度相近的子树再挂接，时间复杂度 O(|h(l) - h(r)| + 1)
+func (a *AVLTree[K, V]) joinNode(l, m, r *avlNode[K, V]) *avlNode[K, V] {
+	if l.height() > r.height()+1 {
+		return a.joinRight(l, m, r)
+	}
+	if r.height() > l.height()+1 {
+		return a.joinLeft(l, m, r)
+	}
+	m.left, m.right = l, r
+	a.update(m)
+	return m
+}
+
+// l 较高时沿 l 的右边界向下挂接
+func (a *AVLTree[K, V]) joinRight(l, m, r *avlNode[K, V]) *avlNode[K, V] {
+	l = a.mut(l)
+	if l.right.height() <= r.height()+1 {
+		m.left, m.right = l.right, r
+		a.update(m)
+		l.right = m
+	} else {
+		l.right = a.joinRight(l.right, m, r)
+	}
+	return a.keepBalance(l)
+}
+
+// r 较高时沿 r 的左边界向下挂接
+func (a *AVLTree[K, V]) joinLeft(l, m, r *avlNode[K, V]) *avlNode[K, V] {
+	r = a.mut(r)
+	if r.left.height() <= l.height()+1 {
+		m.left, m.right = l, r.left
+		a.update(m)
+		r.left = m
+	} else {
+		r.left = a.joinLeft(l, m, r.left)
+	}
+	return a.keepBalance(r)
+}
+
+// 没有中间节点时，取出 r 的最小节点作为中间节点连接
+func (a *AVLTree[K, V]) join2(l, r *avlNode[K, V]) *avlNode[K, V] {
+	if r == nil {
+		return l
+	}
+	rest, m := a.splitMin(r)
+	return a.joinNode(l, m, rest)
+}
+
+// 摘除子树中的最小节点，返回剩余部分和最小节点
+func (a *AVLTree[K, V]) splitMin(t *avlNode[K, V]) (rest, m *avlNode[K, V]) {
+	t = a.mut(t)
+	if t.left == nil {
+		rest = t.right
+		t.right = nil
+		a.update(t)
+		return rest, t
+	}
+	t.left, m = a.splitMin(t.left)
+	return a.keepBalance(t), m
+}
@@ -0,0 +1,173 @@
+package collections
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randAVL(n, limit int) (*AVLTree[int, int], map[int]bool) {
+	tree := NewAVLTree[int, int]()
+	keys := make(map[int]bool)
+	for i := 0; i < n; i++ {
+		k := rand.Intn(limit)
+		tree.Put(k, k*10)
+		keys[k] = true
+	}
+	return tree, keys
+}
+
+func TestAVLTreeSplit(t *testing.T) {
+	for i := 0; i < maxNum; i++ {
+		tree, keys := randAVL(rand.Intn(maxNum), maxNum*2)
+		k := rand.Intn(maxNum*2+2) - 1
+		left, right := tree.Split(k)
+		assert.Equal(t, 0, tree.Len())
+		assert.Nil(t, left.Validate())
+		assert.Nil(t, right.Validate())
+		assert.Equal(t, len(keys), left.Len()+right.Len())
+		for _, key := range left.AllValues() {
+			assert.True(t, key < k && keys[key])
+		}
+		for _, key := range right.AllValues() {
+			assert.True(t, key >= k && keys[key])
+		}
+		assert.Equal(t, keys[k], right.Contains(k))
+
+		joined := JoinAVLTree(left, right)
+		assert.Nil(t, joined.Validate())
+		assert.Equal(t, len(keys), joined.Len())
+		assert.Equal(t, 0, left.Len()+right.Len())
+		for key := range keys {
+			v, ok := joined.Get(key)
+			assert.True(t, ok)
+			assert.Equal(t, key*10, v)
+		}
+	}
+}
+
+func TestAVLTreeJoinUnbalanced(t *testing.T) {
+	left := NewAVLTree[int, int]()
+	right := NewAVLTree[int, int]()
+	for i := 0; i < 1000; i++ {
+		left.Insert(i)
+	}
+	right.Insert(1000)
+	joined := JoinAVLTree(left, right)
+	assert.Nil(t, joined.Validate())
+	assert.Equal(t, 1001, joined.Len())
+
+	small := NewAVLTree[int, int]()
+	small.Insert(-1)
+	joined = JoinAVLTree(small, joined)
+	assert.Nil(t, joined.Validate())
+	assert.Equal(t, 1002, joined.Len())
+
+	bad := NewAVLTree[int, int]()
+	bad.Insert(0)
+	assert.Panics(t, func() { JoinAVLTree(joined, bad) })
+}
+
+func TestAVLTreeSetOperations(t *testing.T) {
+	for i := 0; i < maxNum; i++ {
+		a, ak := randAVL(rand.Intn(maxNum), maxNum)
+		b, bk := randAVL(rand.Intn(maxNum), maxNum)
+		a2 := NewAVLTree[int, int]()
+		a2.BuildFrom(a.AllValues())
+		b2 := NewAVLTree[int, int]()
+		b2.BuildFrom(b.AllValues())
+		a3 := NewAVLTree[int, int]()
+		a3.BuildFrom(a.AllValues())
+		b3 := NewAVLTree[int, int]()
+		b3.BuildFrom(b.AllValues())
+
+		union, inter, diff := make([]int, 0), make([]int, 0), make([]int, 0)
+		for k := 0; k < maxNum; k++ {
+			if ak[k] || bk[k] {
+				union = append(union, k)
+			}
+			if ak[k] && bk[k] {
+				inter = append(inter, k)
+			}
+			if ak[k] && !bk[k] {
+				diff = append(diff, k)
+			}
+		}
+
+		a.Union(b)
+		assert.Nil(t, a.Validate())
+		assert.Equal(t, union, a.AllValues())
+		assert.Equal(t, 0, b.Len())
+		for _, k := range a.AllValues() {
+			v, _ := a.Get(k)
+			assert.Equal(t, k*10, v)
+		}
+
+		a2.Intersection(b2)
+		assert.Nil(t, a2.Validate())
+		assert.Equal(t, inter, a2.AllValues())
+
+		a3.Difference(b3)
+		assert.Nil(t, a3.Validate())
+		assert.Equal(t, diff, a3.AllValues())
+	}
+}
+
+func TestAVLMultiTreeSetOperations(t *testing.T) {
+	build := func(keys ...int) *AVLTree[int, struct{}] {
+		tree := NewAVLMultiTree[int, struct{}]()
+		tree.BuildFrom(keys)
+		return tree
+	}
+	a := build(1, 1, 2, 3, 3, 3)
+	a.Union(build(1, 3, 4))
+	assert.Nil(t, a.Validate())
+	assert.Equal(t, []int{1, 1, 1, 2, 3, 3, 3, 3, 4}, a.AllValues())
+
+	a = build(1, 1, 2, 3, 3, 3)
+	a.Intersection(build(1, 3, 3, 4))
+	assert.Nil(t, a.Validate())
+	assert.Equal(t, []int{1, 3, 3}, a.AllValues())
+
+	a = build(1, 1, 2, 3, 3, 3)
+	a.Difference(build(1, 2, 3, 3, 4))
+	assert.Nil(t, a.Validate())
+	assert.Equal(t, []int{1, 3}, a.AllValues())
+}
+
+func TestAVLTreeSetOperationsSelf(t *testing.T) {
+	for _, multi := range []bool{false, true} {
+		tree := NewAVLTree[int, int]()
+		if multi {
+			tree = NewAVLMultiTree[int, int]()
+		}
+		tree.BuildFrom([]int{1, 2, 2, 3})
+		want := tree.AllValues()
+
+		tree.Union(tree)
+		assert.Equal(t, want, tree.AllValues())
+		tree.Intersection(tree)
+		assert.Equal(t, want, tree.AllValues())
+		tree.Difference(tree)
+		assert.Equal(t, 0, tree.Len())
+		assert.Nil(t, tree.Validate())
+	}
+}
+
+func TestAVLTreeSetOperationsMixedMode(t *testing.T) {
+	set := NewAVLTree[int, int]()
+	set.BuildFrom([]int{1, 2})
+	multi := NewAVLMultiTree[int, int]()
+	multi.BuildFrom([]int{2, 2, 3})
+
+	assert.Panics(t, func() { set.Union(multi) })
+	assert.Panics(t, func() { set.Intersection(multi) })
+	assert.Panics(t, func() { set.Difference(multi) })
+	assert.Panics(t, func() { multi.Union(set) })
+	assert.Panics(t, func() { JoinAVLTree(set, multi) })
+	// panic 时两棵树都保持不变
+	assert.Equal(t, []int{1, 2}, set.AllValues())
+	assert.Equal(t, []int{2, 2, 3}, multi.AllValues())
+	assert.Nil(t, set.Validate())
+}
@@ -0,0 +1,132 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+)
+
+// PersistentAVLTree 不可变的 AVL 树，修改操作通过路径复制返回新的树，与原树共享未修改的子树
+// 任意版本都可以在多个 goroutine 中并发读取
+type PersistentAVLTree[K, V any] struct {
+	t *AVLTree[K, V]
+}
+
+// 生成不可变 AVL 树，键类型需满足 cmp.Ordered
+func NewPersistentAVLTree[K cmp.Ordered, V any]() *PersistentAVLTree[K, V] {
+	return NewPersistentAVLTreeFunc[K, V](cmp.Compare[K])
+}
+
+// 使用自定义比较函数生成不可变 AVL 树
+func NewPersistentAVLTreeFunc[K, V any](cmp func(a, b K) int) *PersistentAVLTree[K, V] {
+	return &PersistentAVLTree[K, V]{NewAVLTreeFunc[K, V](cmp)}
+}
+
+// 以 O(1) 生成当前树的不可变快照
+// 快照与原树共享所有节点，之后原树的修改会复制涉及的节点，快照的内容保持不变
+func (a *AVLTree[K, V]) Snapshot() *PersistentAVLTree[K, V] {
+	p := &PersistentAVLTree[K, V]{a.fork()}
+	// 更换版本号后，原树中的所有节点都视为共享节点
+	a.gen = avlGen.Add(1)
+	return p
+}
+
+// 以 O(1) 生成与快照内容相同的可修改 AVL 树
+func (p *PersistentAVLTree[K, V]) Mutable() *AVLTree[K, V] {
+	return p.t.fork()
+}
+
+// 插入或更新键值对，返回新的树
+func (p *PersistentAVLTree[K, V]) Put(k K, v V) *PersistentAVLTree[K, V] {
+	t := p.t.fork()
+	t.Put(k, v)
+	return &PersistentAVLTree[K, V]{t}
+}
+
+// 插入节点，返回新的树
+func (p *PersistentAVLTree[K, V]) Insert(k K) *PersistentAVLTree[K, V] {
+	var zero V
+	return p.Put(k, zero)
+}
+
+// 删除节点，返回新的树，多重集合模式下删除该键的所有元素
+func (p *PersistentAVLTree[K, V]) Delete(k K) *PersistentAVLTree[K, V] {
+	if !p.t.Contains(k) {
+		return p
+	}
+	t := p.t.fork()
+	t.Delete(k)
+	return &PersistentAVLTree[K, V]{t}
+}
+
+// 删除键 k 的一个元素，返回新的树
+func (p *PersistentAVLTree[K, V]) DeleteOne(k K) *PersistentAVLTree[K, V] {
+	if !p.t.Contains(k) {
+		return p
+	}
+	t := p.t.fork()
+	t.DeleteOne(k)
+	return &PersistentAVLTree[K, V]{t}
+}
+
+// 获取键对应的值
+func (p *PersistentAVLTree[K, V]) Get(k K) (V, bool) { return p.t.Get(k) }
+
+// 判断键是否存在
+func (p *PersistentAVLTree[K, V]) Contains(k K) bool { return p.t.Contains(k) }
+
+// 搜索节点
+func (p *PersistentAVLTree[K, V]) Search(k K) bool { return p.t.Search(k) }
+
+// 返回键 k 的元素数
+func (p *PersistentAVLTree[K, V]) Count(k K) int { return p.t.Count(k) }
+
+// 元素数量
+func (p *PersistentAVLTree[K, V]) Len() int { return p.t.Len() }
+
+// 获取所有节点中的最大键，空树返回 false
+func (p *PersistentAVLTree[K, V]) GetMaxValue() (K, bool) { return p.t.GetMaxValue() }
+
+// 获取所有节点中的最小键，空树返回 false
+func (p *PersistentAVLTree[K, V]) GetMinValue() (K, bool) { return p.t.GetMinValue() }
+
+// 返回排序后所有键
+func (p *PersistentAVLTree[K, V]) AllValues() []K { return p.t.AllValues() }
+
+// 按键的顺序返回所有值
+func (p *PersistentAVLTree[K, V]) Values() []V { return p.t.Values() }
+
+// 返回小于 k 的键的数量
+func (p *PersistentAVLTree[K, V]) Rank(k K) int { return p.t.Rank(k) }
+
+// 返回第 i 小的键值对，i 从 0 开始
+func (p *PersistentAVLTree[K, V]) Select(i int) (K, V, bool) { return p.t.Select(i) }
+
+// 返回小于等于 k 的最大键值对
+func (p *PersistentAVLTree[K, V]) Floor(k K) (K, V, bool) { return p.t.Floor(k) }
+
+// 返回大于等于 k 的最小键值对
+func (p *PersistentAVLTree[K, V]) Ceiling(k K) (K, V, bool) { return p.t.Ceiling(k) }
+
+// 返回严格小于 k 的最大键值对
+func (p *PersistentAVLTree[K, V]) Lower(k K) (K, V, bool) { return p.t.Lower(k) }
+
+// 返回严格大于 k 的最小键值对
+func (p *PersistentAVLTree[K, V]) Higher(k K) (K, V, bool) { return p.t.Higher(k) }
+
+// 按键升序遍历区间内的键值对，fn 返回 false 时停止遍历
+func (p *PersistentAVLTree[K, V]) Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool) {
+	p.t.Range(lo, hi, bound, fn)
+}
+
+// 返回按键升序遍历的迭代器
+func (p *PersistentAVLTree[K, V]) Ascend() iter.Seq2[K, V] { return p.t.Ascend() }
+
+// 返回按键降序遍历的迭代器
+func (p *PersistentAVLTree[K, V]) Descend() iter.Seq2[K, V] { return p.t.Descend() }
+
+// 生成与 a 共享所有节点的新版本树，新版本修改时只复制涉及的路径
+func (a *AVLTree[K, V]) fork() *AVLTree[K, V] {
+	t := a.empty()
+	t.setRoot(a.tree)
+	return t
+}
@@ -0,0 +1,111 @@
+package collections
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistentAVLTree(t *testing.T) {
+	versions := []*PersistentAVLTree[int, int]{NewPersistentAVLTree[int, int]()}
+	for i := 0; i < maxNum; i++ {
+		versions = append(versions, versions[i].Put(i, i*10))
+	}
+	for i, p := range versions {
+		assert.Equal(t, i, p.Len())
+		assert.Nil(t, p.t.Validate())
+		assert.Equal(t, i > 0, p.Contains(i-1))
+		assert.False(t, p.Contains(i))
+	}
+
+	last := versions[maxNum]
+	removed := last.Delete(50).DeleteOne(60)
+	assert.Equal(t, maxNum-2, removed.Len())
+	assert.False(t, removed.Contains(50))
+	assert.True(t, last.Contains(50))
+	assert.True(t, last.Contains(60))
+	assert.Equal(t, last, last.Delete(-1))
+
+	updated := last.Put(10, -1)
+	v, _ := updated.Get(10)
+	assert.Equal(t, -1, v)
+	v, _ = last.Get(10)
+	assert.Equal(t, 100, v)
+}
+
+func TestAVLTreeSnapshot(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	for i := 0; i < maxNum; i++ {
+		tree.Put(i, i)
+	}
+	snap := tree.Snapshot()
+	want := snap.AllValues()
+
+	for i := 0; i < maxNum; i += 2 {
+		tree.Delete(i)
+		tree.Put(i+maxNum, i)
+		tree.Put(i+1, -1)
+	}
+	left, right := tree.Split(maxNum / 2)
+	left.Union(right)
+	assert.Nil(t, left.Validate())
+
+	assert.Equal(t, want, snap.AllValues())
+	assert.Nil(t, snap.t.Validate())
+	for i := 0; i < maxNum; i++ {
+		v, ok := snap.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+
+	m := snap.Mutable()
+	m.Delete(0)
+	assert.True(t, snap.Contains(0))
+	assert.Equal(t, maxNum-1, m.Len())
+}
+
+func TestAVLTreeSnapshotEmpty(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	snap := tree.Snapshot()
+	tree.Put(1, 1)
+	// 空树的快照不受之后写入的影响
+	assert.Equal(t, 0, snap.Len())
+	assert.False(t, snap.Contains(1))
+	_, ok := snap.GetMinValue()
+	assert.False(t, ok)
+
+	empty := NewPersistentAVLTree[int, int]()
+	one := empty.Put(1, 1)
+	assert.Equal(t, 0, empty.Len())
+	assert.Equal(t, 1, one.Len())
+	mutable := empty.Mutable()
+	mutable.Put(2, 2)
+	assert.Equal(t, 0, empty.Len())
+	assert.False(t, one.Contains(2))
+}
+
+func TestAVLTreeSnapshotConcurrentRead(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	for i := 0; i < maxNum; i++ {
+		tree.Insert(i)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		snap := tree.Snapshot()
+		wg.Add(1)
+		go func(snap *PersistentAVLTree[int, int], n int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				assert.Equal(t, n, snap.Len())
+				assert.True(t, assertSort(snap.AllValues()))
+			}
+		}(snap, tree.Len())
+		for j := 0; j < maxNum; j++ {
+			tree.Insert(rand.Int())
+			tree.Delete(rand.Intn(maxNum))
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,94 @@
+package collections
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 以 Graphviz DOT 格式输出树结构，节点标注键、树高 h 和平衡因子 bf
+// 可以通过 `dot -Tpng tree.dot -o tree.png` 生成图片
+func (a *AVLTree[K, V]) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph AVLTree {\n")
+	bw.WriteString("\tnode [shape=record, fontname=\"monospace\"];\n")
+	id := 0
+	writeDOTNode(bw, a.tree, &id)
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// 前序遍历输出节点和边，返回节点编号
+// 只有一个子节点时用空节点占位，保持左右方向
+func writeDOTNode[K, V any](w *bufio.Writer, t *avlNode[K, V], id *int) int {
+	cur := *id
+	*id++
+	if t == nil {
+		fmt.Fprintf(w, "\tn%d [shape=point];\n", cur)
+		return cur
+	}
+	label := fmt.Sprint(t.key)
+	if t.c > 1 {
+		label += fmt.Sprintf(" ×%d", t.c)
+	}
+	fmt.Fprintf(w, "\tn%d [label=\"{%s|h=%d bf=%d}\"];\n", cur, escapeDOT(label), t.h, t.left.height()-t.right.height())
+	if t.left == nil && t.right == nil {
+		return cur
+	}
+	fmt.Fprintf(w, "\tn%d -> n%d [label=\"L\"];\n", cur, writeDOTNode(w, t.left, id))
+	fmt.Fprintf(w, "\tn%d -> n%d [label=\"R\"];\n", cur, writeDOTNode(w, t.right, id))
+	return cur
+}
+
+// 转义 record 标签中的特殊字符，结果可直接放在 DOT 的双引号字符串中
+func escapeDOT(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`,
+		`{`, `\{`, `}`, `\}`, `|`, `\|`, `<`, `\<`, `>`, `\>`).Replace(s)
+}
+
+// 将树横向打印，右子树在上，左子树在下，便于在终端中比较树的形状
+//
+//	┌── 3
+//	2
+//	└── 1
+func (a *AVLTree[K, V]) String() string {
+	root := a.tree
+	if root == nil {
+		return "(empty)"
+	}
+	var sb strings.Builder
+	writeTreeNode(&sb, root, "", "")
+	return sb.String()
+}
+
+// prefix 为当前节点所在行的缩进，branch 为连接父节点的分支符号
+func writeTreeNode[K, V any](sb *strings.Builder, t *avlNode[K, V], prefix, branch string) {
+	if t.right != nil {
+		next := prefix + "│   "
+		if branch != "└── " {
+			next = prefix + "    "
+		}
+		if branch == "" {
+			next = prefix
+		}
+		writeTreeNode(sb, t.right, next, "┌── ")
+	}
+	sb.WriteString(prefix)
+	sb.WriteString(branch)
+	fmt.Fprint(sb, t.key)
+	if t.c > 1 {
+		fmt.Fprintf(sb, " ×%d", t.c)
+	}
+	sb.WriteByte('\n')
+	if t.left != nil {
+		next := prefix + "│   "
+		if branch != "┌── " {
+			next = prefix + "    "
+		}
+		if branch == "" {
+			next = prefix
+		}
+		writeTreeNode(sb, t.left, next, "└── ")
+	}
+}
@@ -0,0 +1,64 @@
+package collections
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAVLTreeString(t *testing.T) {
+	tree := NewAVLTree[int, struct{}]()
+	assert.Equal(t, "(empty)", tree.String())
+	for i := 1; i <= 7; i++ {
+		tree.Insert(i)
+	}
+	want := strings.Join([]string{
+		"    ┌── 7",
+		"┌── 6",
+		"│   └── 5",
+		"4",
+		"│   ┌── 3",
+		"└── 2",
+		"    └── 1",
+		"",
+	}, "\n")
+	assert.Equal(t, want, tree.String())
+
+	multi := NewAVLMultiTree[string, struct{}]()
+	multi.Insert("b")
+	multi.Insert("a")
+	multi.Insert("a")
+	assert.Equal(t, "b\n└── a ×2\n", multi.String())
+}
+
+func TestAVLTreeWriteDOT(t *testing.T) {
+	tree := NewAVLTree[string, int]()
+	tree.Insert("b")
+	tree.Insert("a")
+	tree.Insert("{c}")
+	var buf bytes.Buffer
+	assert.Nil(t, tree.WriteDOT(&buf))
+	dot := buf.String()
+	assert.True(t, strings.HasPrefix(dot, "digraph AVLTree {\n"))
+	assert.Contains(t, dot, `n0 [label="{b|h=1 bf=0}"];`)
+	assert.Contains(t, dot, `n1 [label="{a|h=0 bf=0}"];`)
+	assert.Contains(t, dot, `n2 [label="{\{c\}|h=0 bf=0}"];`)
+	assert.Equal(t, `\"a\\b\"\|\<c\>`, escapeDOT(`"a\b"|<c>`))
+	assert.Contains(t, dot, `n0 -> n1 [label="L"];`)
+	assert.Contains(t, dot, `n0 -> n2 [label="R"];`)
+
+	tree.Delete("a")
+	buf.Reset()
+	assert.Nil(t, tree.WriteDOT(&buf))
+	assert.Contains(t, buf.String(), `n0 [label="{b|h=1 bf=-1}"];`)
+	assert.Contains(t, buf.String(), `n1 [shape=point];`)
+
+	assert.NotNil(t, tree.WriteDOT(failWriter{}))
+}
+
+type failWriter struct{}
+
+func (failWriter) Write(p []byte) (int, error) { return 0, errors.New("write failed") }
@@ -0,0 +1,338 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// AVLTreeReader AVL 树的只读方法集，AVLTree 和 PersistentAVLTree 均实现了该接口
+type AVLTreeReader[K, V any] interface {
+	Get(k K) (V, bool)
+	Contains(k K) bool
+	Search(k K) bool
+	Count(k K) int
+	Len() int
+	GetMaxValue() (K, bool)
+	GetMinValue() (K, bool)
+	AllValues() []K
+	Values() []V
+	Rank(k K) int
+	Select(i int) (K, V, bool)
+	Floor(k K) (K, V, bool)
+	Ceiling(k K) (K, V, bool)
+	Lower(k K) (K, V, bool)
+	Higher(k K) (K, V, bool)
+	Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool)
+	Ascend() iter.Seq2[K, V]
+	Descend() iter.Seq2[K, V]
+}
+
+var (
+	_ AVLTreeReader[int, int] = (*AVLTree[int, int])(nil)
+	_ AVLTreeReader[int, int] = (*PersistentAVLTree[int, int])(nil)
+	_ AVLTreeReader[int, int] = avlTreeView[int, int]{}
+)
+
+// SyncAVLTree 线程安全的 AVL 树，所有操作由读写锁保护
+// 遍历基于调用时刻的快照进行，遍历过程中不持有锁，也不受并发写入的影响
+type SyncAVLTree[K, V any] struct {
+	tree  *AVLTree[K, V]
+	multi bool // 创建后不再改变，可以不加锁读取
+	mut   *sync.RWMutex
+	// 上次修改之后是否生成过快照，下一次修改前需要更换版本号，避免原地修改快照共享的节点
+	shared atomic.Bool
+}
+
+// 生成线程安全的 AVL 树，键类型需满足 cmp.Ordered
+func NewSyncAVLTree[K cmp.Ordered, V any]() *SyncAVLTree[K, V] {
+	return NewSyncAVLTreeFunc[K, V](cmp.Compare[K])
+}
+
+// 使用自定义比较函数生成线程安全的 AVL 树
+func NewSyncAVLTreeFunc[K, V any](cmp func(a, b K) int) *SyncAVLTree[K, V] {
+	return newSyncAVLTree(NewAVLTreeFunc[K, V](cmp))
+}
+
+// 生成多重集合模式的线程安全 AVL 树
+func NewSyncAVLMultiTree[K cmp.Ordered, V any]() *SyncAVLTree[K, V] {
+	return NewSyncAVLMultiTreeFunc[K, V](cmp.Compare[K])
+}
+
+// 使用自定义比较函数生成多重集合模式的线程安全 AVL 树
+func NewSyncAVLMultiTreeFunc[K, V any](cmp func(a, b K) int) *SyncAVLTree[K, V] {
+	return newSyncAVLTree(NewAVLMultiTreeFunc[K, V](cmp))
+}
+
+func newSyncAVLTree[K, V any](tree *AVLTree[K, V]) *SyncAVLTree[K, V] {
+	return &SyncAVLTree[K, V]{tree: tree, multi: tree.multi, mut: new(sync.RWMutex)}
+}
+
+// 获取写锁，上次修改之后生成过快照时更换版本号，之后的修改只复制涉及的节点
+func (s *SyncAVLTree[K, V]) lock() {
+	s.mut.Lock()
+	if s.shared.Swap(false) {
+		s.tree.gen = avlGen.Add(1)
+	}
+}
+
+// 在读锁内执行 fn，fn 中的多次查询看到的是同一时刻的数据
+// view 只提供查询方法，只能在 fn 内使用
+func (s *SyncAVLTree[K, V]) Read(fn func(view AVLTreeReader[K, V])) {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	fn(avlTreeView[K, V]{s.tree})
+}
+
+// 在写锁内执行 fn，fn 中的多次修改对其他 goroutine 是原子的
+// tx 只能在 fn 内使用
+func (s *SyncAVLTree[K, V]) Update(fn func(tx *AVLTree[K, V])) {
+	defer s.mut.Unlock()
+	s.lock()
+	fn(s.tree)
+}
+
+// 以 O(1) 生成当前内容的不可变快照，可以在不持有锁的情况下长时间读取
+// 只需要读锁，快照之后的第一次修改会复制涉及的路径
+func (s *SyncAVLTree[K, V]) Snapshot() *PersistentAVLTree[K, V] {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	s.shared.Store(true)
+	return &PersistentAVLTree[K, V]{s.tree.fork()}
+}
+
+func (s *SyncAVLTree[K, V]) Put(k K, v V) {
+	defer s.mut.Unlock()
+	s.lock()
+	s.tree.Put(k, v)
+}
+
+func (s *SyncAVLTree[K, V]) Get(k K) (V, bool) {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Get(k)
+}
+
+func (s *SyncAVLTree[K, V]) Contains(k K) bool {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Contains(k)
+}
+
+func (s *SyncAVLTree[K, V]) Insert(k K) {
+	defer s.mut.Unlock()
+	s.lock()
+	s.tree.Insert(k)
+}
+
+func (s *SyncAVLTree[K, V]) Search(k K) bool {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Search(k)
+}
+
+func (s *SyncAVLTree[K, V]) Count(k K) int {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Count(k)
+}
+
+func (s *SyncAVLTree[K, V]) Delete(k K) bool {
+	defer s.mut.Unlock()
+	s.lock()
+	return s.tree.Delete(k)
+}
+
+func (s *SyncAVLTree[K, V]) DeleteOne(k K) bool {
+	defer s.mut.Unlock()
+	s.lock()
+	return s.tree.DeleteOne(k)
+}
+
+func (s *SyncAVLTree[K, V]) DeleteAll(k K) int {
+	defer s.mut.Unlock()
+	s.lock()
+	return s.tree.DeleteAll(k)
+}
+
+func (s *SyncAVLTree[K, V]) Len() int {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Len()
+}
+
+func (s *SyncAVLTree[K, V]) GetMaxValue() (K, bool) {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.GetMaxValue()
+}
+
+func (s *SyncAVLTree[K, V]) GetMinValue() (K, bool) {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.GetMinValue()
+}
+
+func (s *SyncAVLTree[K, V]) PopMin() (K, V, bool) {
+	defer s.mut.Unlock()
+	s.lock()
+	return s.tree.PopMin()
+}
+
+func (s *SyncAVLTree[K, V]) PopMax() (K, V, bool) {
+	defer s.mut.Unlock()
+	s.lock()
+	return s.tree.PopMax()
+}
+
+func (s *SyncAVLTree[K, V]) AllValues() []K {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.AllValues()
+}
+
+func (s *SyncAVLTree[K, V]) Values() []V {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Values()
+}
+
+func (s *SyncAVLTree[K, V]) Rank(k K) int {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Rank(k)
+}
+
+func (s *SyncAVLTree[K, V]) Select(i int) (K, V, bool) {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Select(i)
+}
+
+func (s *SyncAVLTree[K, V]) Kth(k int) (K, V, bool) {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Kth(k)
+}
+
+func (s *SyncAVLTree[K, V]) Floor(k K) (K, V, bool) {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Floor(k)
+}
+
+func (s *SyncAVLTree[K, V]) Ceiling(k K) (K, V, bool) {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Ceiling(k)
+}
+
+func (s *SyncAVLTree[K, V]) Lower(k K) (K, V, bool) {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Lower(k)
+}
+
+func (s *SyncAVLTree[K, V]) Higher(k K) (K, V, bool) {
+	defer s.mut.RUnlock()
+	s.mut.RLock()
+	return s.tree.Higher(k)
+}
+
+// 按 k 将树切分为两棵线程安全的树，left 中的键都小于 k，right 中的键都大于等于 k
+// 切分后原树为空
+func (s *SyncAVLTree[K, V]) Split(k K) (left, right *SyncAVLTree[K, V]) {
+	defer s.mut.Unlock()
+	s.lock()
+	l, r := s.tree.Split(k)
+	return newSyncAVLTree(l), newSyncAVLTree(r)
+}
+
+// 并集，b 中的键值对合并到 s 中，合并后 b 为空，b 为 s 自身时不做任何操作
+// 先取出 b 的内容再锁住 s，不会同时持有两把锁，s.Union(b) 与 b.Union(s) 并发执行也不会死锁
+func (s *SyncAVLTree[K, V]) Union(b *SyncAVLTree[K, V]) {
+	if s == b {
+		return
+	}
+	t := b.take(s)
+	defer s.mut.Unlock()
+	s.lock()
+	s.tree.Union(t)
+}
+
+// 交集，s 中只保留同时存在于 b 中的键，求交集后 b 为空，b 为 s 自身时不做任何操作
+func (s *SyncAVLTree[K, V]) Intersection(b *SyncAVLTree[K, V]) {
+	if s == b {
+		return
+	}
+	t := b.take(s)
+	defer s.mut.Unlock()
+	s.lock()
+	s.tree.Intersection(t)
+}
+
+// 差集，从 s 中移除存在于 b 中的键，求差集后 b 为空，b 为 s 自身时 s 被清空
+func (s *SyncAVLTree[K, V]) Difference(b *SyncAVLTree[K, V]) {
+	if s == b {
+		s.take(s)
+		return
+	}
+	t := b.take(s)
+	defer s.mut.Unlock()
+	s.lock()
+	s.tree.Difference(t)
+}
+
+// 取出树的全部内容，原树变为空树，模式与 dst 不同时 panic 且不修改原树
+func (s *SyncAVLTree[K, V]) take(dst *SyncAVLTree[K, V]) *AVLTree[K, V] {
+	checkAVLMode(s.multi, dst.multi)
+	defer s.mut.Unlock()
+	s.lock()
+	t := s.tree
+	s.tree = t.empty()
+	return t
+}
+
+// 在快照上按键升序遍历区间内的键值对，fn 中可以修改树
+// 快照只需要读锁，不阻塞其他读操作，但遍历之后的第一次修改会复制涉及的路径
+func (s *SyncAVLTree[K, V]) Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool) {
+	s.Snapshot().Range(lo, hi, bound, fn)
+}
+
+// 返回在快照上按键升序遍历的迭代器，遍历过程中可以修改树，开销与 Range 相同
+func (s *SyncAVLTree[K, V]) Ascend() iter.Seq2[K, V] {
+	return s.Snapshot().Ascend()
+}
+
+// 返回在快照上按键降序遍历的迭代器，遍历过程中可以修改树，开销与 Range 相同
+func (s *SyncAVLTree[K, V]) Descend() iter.Seq2[K, V] {
+	return s.Snapshot().Descend()
+}
+
+// 只暴露查询方法的 AVL 树视图，传给 Read 的回调，避免在读锁内修改树
+type avlTreeView[K, V any] struct {
+	t *AVLTree[K, V]
+}
+
+func (v avlTreeView[K, V]) Get(k K) (V, bool)         { return v.t.Get(k) }
+func (v avlTreeView[K, V]) Contains(k K) bool         { return v.t.Contains(k) }
+func (v avlTreeView[K, V]) Search(k K) bool           { return v.t.Search(k) }
+func (v avlTreeView[K, V]) Count(k K) int             { return v.t.Count(k) }
+func (v avlTreeView[K, V]) Len() int                  { return v.t.Len() }
+func (v avlTreeView[K, V]) GetMaxValue() (K, bool)    { return v.t.GetMaxValue() }
+func (v avlTreeView[K, V]) GetMinValue() (K, bool)    { return v.t.GetMinValue() }
+func (v avlTreeView[K, V]) AllValues() []K            { return v.t.AllValues() }
+func (v avlTreeView[K, V]) Values() []V               { return v.t.Values() }
+func (v avlTreeView[K, V]) Rank(k K) int              { return v.t.Rank(k) }
+func (v avlTreeView[K, V]) Select(i int) (K, V, bool) { return v.t.Select(i) }
+func (v avlTreeView[K, V]) Floor(k K) (K, V, bool)    { return v.t.Floor(k) }
+func (v avlTreeView[K, V]) Ceiling(k K) (K, V, bool)  { return v.t.Ceiling(k) }
+func (v avlTreeView[K, V]) Lower(k K) (K, V, bool)    { return v.t.Lower(k) }
+func (v avlTreeView[K, V]) Higher(k K) (K, V, bool)   { return v.t.Higher(k) }
+func (v avlTreeView[K, V]) Ascend() iter.Seq2[K, V]   { return v.t.Ascend() }
+func (v avlTreeView[K, V]) Descend() iter.Seq2[K, V]  { return v.t.Descend() }
+
+func (v avlTreeView[K, V]) Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool) {
+	v.t.Range(lo, hi, bound, fn)
+}
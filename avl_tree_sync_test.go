@@ -0,0 +1,240 @@
+package collections
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncAVLTree(t *testing.T) {
+	tree := NewSyncAVLTree[int, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < nums; i++ {
+				tree.Put(g*nums+i, i)
+				tree.Get(i)
+				tree.Len()
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, 4*nums, tree.Len())
+	assert.True(t, assertSort(tree.AllValues()))
+	minKey, _ := tree.GetMinValue()
+	assert.Equal(t, 0, minKey)
+	maxKey, _ := tree.GetMaxValue()
+	assert.Equal(t, 4*nums-1, maxKey)
+	assert.True(t, tree.Delete(0))
+	assert.False(t, tree.Search(0))
+	assert.Equal(t, 1, tree.Rank(2))
+}
+
+func TestSyncAVLTreeIterateWhileWriting(t *testing.T) {
+	tree := NewSyncAVLTree[int, int]()
+	for i := 0; i < nums; i++ {
+		tree.Insert(i)
+	}
+	// 遍历过程中修改树不会死锁，也不会影响本次遍历的结果
+	i := 0
+	for k := range tree.Ascend() {
+		assert.Equal(t, i, k)
+		tree.Delete(k)
+		tree.Insert(k + nums)
+		i++
+	}
+	assert.Equal(t, nums, i)
+	minKey, ok := tree.GetMinValue()
+	assert.True(t, ok)
+	assert.Equal(t, nums, minKey)
+
+	n := 0
+	tree.Range(nums, 2*nums, IncludeLo, func(k, v int) bool {
+		tree.Delete(k)
+		n++
+		return true
+	})
+	assert.Equal(t, nums, n)
+	assert.Equal(t, 0, tree.Len())
+}
+
+func TestSyncAVLTreeReadUpdate(t *testing.T) {
+	tree := NewSyncAVLTree[string, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < nums; i++ {
+				// 转账：两个键的值之和始终为 0
+				tree.Update(func(tx *AVLTree[string, int]) {
+					a, _ := tx.Get("a")
+					b, _ := tx.Get("b")
+					tx.Put("a", a+1)
+					tx.Put("b", b-1)
+				})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < nums; i++ {
+				tree.Read(func(view AVLTreeReader[string, int]) {
+					// 视图不能转换回可修改的树
+					_, ok := view.(*AVLTree[string, int])
+					assert.False(t, ok)
+					a, _ := view.Get("a")
+					b, _ := view.Get("b")
+					assert.Equal(t, 0, a+b)
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	v, _ := tree.Get("a")
+	assert.Equal(t, 4*nums, v)
+}
+
+func TestSyncAVLTreePop(t *testing.T) {
+	tree := NewSyncAVLTree[int, int]()
+	for i := 0; i < 4*nums; i++ {
+		tree.Insert(i)
+	}
+	// 并发地从两端弹出，每个键只会被弹出一次
+	popped := make([][]int, 4)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for {
+				pop := tree.PopMin
+				if g%2 == 1 {
+					pop = tree.PopMax
+				}
+				k, _, ok := pop()
+				if !ok {
+					return
+				}
+				popped[g] = append(popped[g], k)
+			}
+		}(g)
+	}
+	wg.Wait()
+	seen := make(map[int]bool)
+	for _, keys := range popped {
+		for _, k := range keys {
+			assert.False(t, seen[k])
+			seen[k] = true
+		}
+	}
+	assert.Equal(t, 4*nums, len(seen))
+	assert.Equal(t, 0, tree.Len())
+}
+
+func TestSyncAVLMultiTree(t *testing.T) {
+	tree := NewSyncAVLMultiTree[int, int]()
+	for i := 0; i < nums; i++ {
+		tree.Insert(i % 10)
+	}
+	assert.Equal(t, nums/10, tree.Count(3))
+	assert.True(t, tree.DeleteOne(3))
+	assert.Equal(t, nums/10-1, tree.Count(3))
+	assert.Equal(t, nums/10, tree.DeleteAll(4))
+	assert.Equal(t, 0, tree.Count(4))
+	assert.Equal(t, nums-nums/10-1, len(tree.Values()))
+
+	k, _, ok := tree.Kth(1)
+	assert.True(t, ok)
+	assert.Equal(t, 0, k)
+	k, _, _ = tree.Lower(5)
+	assert.Equal(t, 3, k)
+	k, _, _ = tree.Higher(3)
+	assert.Equal(t, 5, k)
+}
+
+func TestSyncAVLTreeSetOperations(t *testing.T) {
+	a, b := NewSyncAVLTree[int, int](), NewSyncAVLTree[int, int]()
+	for i := 0; i < nums; i++ {
+		a.Put(i, i)
+		b.Put(i+nums/2, -i)
+	}
+	// 并发地互相合并不会死锁
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		a.Union(b)
+	}()
+	go func() {
+		defer wg.Done()
+		b.Union(a)
+	}()
+	wg.Wait()
+	// 两次合并的先后顺序不确定，最后再合并一次得到所有的键
+	a.Union(b)
+	assert.Equal(t, nums+nums/2, a.Len())
+	assert.Equal(t, 0, b.Len())
+
+	left, right := a.Split(nums)
+	assert.Equal(t, 0, a.Len())
+	assert.Equal(t, nums, left.Len())
+	assert.Equal(t, nums/2, right.Len())
+
+	other := NewSyncAVLTree[int, int]()
+	for i := 0; i < nums; i += 2 {
+		other.Insert(i)
+	}
+	left.Intersection(other)
+	assert.Equal(t, nums/2, left.Len())
+	assert.Equal(t, 0, other.Len())
+
+	other.Insert(0)
+	left.Difference(other)
+	assert.False(t, left.Search(0))
+	assert.Equal(t, nums/2-1, left.Len())
+}
+
+func TestSyncAVLTreeSnapshotIsolation(t *testing.T) {
+	tree := NewSyncAVLTree[int, int]()
+	for i := 0; i < nums; i++ {
+		tree.Put(i, i)
+	}
+	snap := tree.Snapshot()
+	// 连续多次遍历只在之后的第一次修改时更换版本号
+	for range tree.Ascend() {
+		break
+	}
+	gen := tree.tree.gen
+	tree.Put(0, -1)
+	assert.NotEqual(t, gen, tree.tree.gen)
+	gen = tree.tree.gen
+	tree.Put(1, -1)
+	assert.Equal(t, gen, tree.tree.gen)
+
+	v, _ := snap.Get(0)
+	assert.Equal(t, 0, v)
+	v, _ = tree.Get(0)
+	assert.Equal(t, -1, v)
+}
+
+func TestSyncAVLTreeSetOperationsSelf(t *testing.T) {
+	tree := NewSyncAVLTree[int, int]()
+	for i := 0; i < nums; i++ {
+		tree.Insert(i)
+	}
+	tree.Union(tree)
+	assert.Equal(t, nums, tree.Len())
+	tree.Intersection(tree)
+	assert.Equal(t, nums, tree.Len())
+	tree.Difference(tree)
+	assert.Equal(t, 0, tree.Len())
+
+	tree.Insert(1)
+	multi := NewSyncAVLMultiTree[int, int]()
+	multi.Insert(1)
+	assert.Panics(t, func() { tree.Union(multi) })
+	assert.Equal(t, 1, multi.Len())
+}
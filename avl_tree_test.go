@@ -2,21 +2,28 @@ package collections
 
 import (
 	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestAVLTree(t *testing.T) {
-	tree := NewAVLTree()
+	tree := NewAVLTree[int, struct{}]()
 	for i := 0; i < maxNum; i++ {
 		tree.Insert(i)
 		tree.Insert(maxNum + i)
 	}
 	assert.Equal(t, len(tree.AllValues()), maxNum*2)
 	assert.True(t, assertSort(tree.AllValues()))
-	assert.Equal(t, tree.GetMaxValue(), 2*maxNum-1)
-	assert.Equal(t, tree.GetMinValue(), 0)
+	maxKey, ok := tree.GetMaxValue()
+	assert.True(t, ok)
+	assert.Equal(t, maxKey, 2*maxNum-1)
+	minKey, ok := tree.GetMinValue()
+	assert.True(t, ok)
+	assert.Equal(t, minKey, 0)
 	assert.True(t, tree.Search(50))
 	assert.True(t, tree.Search(100))
 	assert.False(t, tree.Search(-10))
@@ -27,7 +34,7 @@ func TestAVLTree(t *testing.T) {
 }
 
 func TestAVLTreeRandom(t *testing.T) {
-	tree := NewAVLTree()
+	tree := NewAVLTree[int, struct{}]()
 	for i := 0; i < maxNum; i++ {
 		tree.Insert(rand.Int())
 	}
@@ -35,8 +42,469 @@ func TestAVLTreeRandom(t *testing.T) {
 	assert.True(t, assertSort(tree.AllValues()))
 }
 
-func genAVL(n int) *AVLTree {
-	t := NewAVLTree()
+func TestAVLTreeEmpty(t *testing.T) {
+	tree := NewAVLTree[int, string]()
+	assert.False(t, tree.Contains(0))
+	assert.False(t, tree.Delete(0))
+	assert.Equal(t, 0, len(tree.AllValues()))
+	_, ok := tree.GetMinValue()
+	assert.False(t, ok)
+	_, ok = tree.GetMaxValue()
+	assert.False(t, ok)
+	_, _, ok = tree.PopMin()
+	assert.False(t, ok)
+	_, _, ok = tree.PopMax()
+	assert.False(t, ok)
+
+	tree.Put(1, "a")
+	assert.True(t, tree.Delete(1))
+	assert.False(t, tree.Contains(1))
+	assert.Nil(t, tree.tree)
+	assert.Equal(t, 0, tree.Len())
+	tree.Put(2, "b")
+	v, ok := tree.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, "b", v)
+	assert.Equal(t, 1, tree.Len())
+}
+
+func TestAVLTreePopMinMax(t *testing.T) {
+	tree := NewAVLMultiTree[int, string]()
+	for i := 0; i < maxNum; i++ {
+		tree.Put(rand.Intn(maxNum/2), strconv.Itoa(i))
+	}
+	keys := tree.AllValues()
+	lo, hi := 0, len(keys)-1
+	for i := 0; lo <= hi; i++ {
+		// 交替从两端弹出，模拟双端优先队列
+		var k int
+		var ok bool
+		if i%2 == 0 {
+			k, _, ok = tree.PopMin()
+			assert.Equal(t, keys[lo], k)
+			lo++
+		} else {
+			k, _, ok = tree.PopMax()
+			assert.Equal(t, keys[hi], k)
+			hi--
+		}
+		assert.True(t, ok)
+		assert.Equal(t, hi-lo+1, tree.Len())
+		assert.NoError(t, tree.Validate())
+	}
+	_, _, ok := tree.PopMin()
+	assert.False(t, ok)
+
+	tree.Put(1, "a")
+	tree.Put(2, "b")
+	k, v, ok := tree.PopMax()
+	assert.True(t, ok)
+	assert.Equal(t, 2, k)
+	assert.Equal(t, "b", v)
+}
+
+func TestAVLTreePutGet(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	for i := 0; i < maxNum; i++ {
+		tree.Put(i, i*10)
+	}
+	for i := 0; i < maxNum; i++ {
+		tree.Put(i, i+1)
+	}
+	for i := 0; i < maxNum; i++ {
+		v, ok := tree.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, i+1, v)
+	}
+	_, ok := tree.Get(-1)
+	assert.False(t, ok)
+	assert.Equal(t, maxNum, len(tree.Values()))
+	assert.True(t, assertSort(tree.Values()))
+}
+
+func TestAVLTreeLargeInt(t *testing.T) {
+	// 大整数相减会溢出，比较函数需要保证顺序正确
+	tree := NewAVLTree[int, struct{}]()
+	values := []int{1<<62 + 1, -(1 << 62), 1 << 62, -(1<<62 + 1), 0}
+	for _, v := range values {
+		tree.Insert(v)
+	}
+	assert.True(t, assertSort(tree.AllValues()))
+	for _, v := range values {
+		assert.True(t, tree.Search(v))
+	}
+}
+
+func TestAVLTreeFunc(t *testing.T) {
+	type point struct{ x, y int }
+	tree := NewAVLTreeFunc[point, string](func(a, b point) int {
+		if a.x != b.x {
+			return a.x - b.x
+		}
+		return a.y - b.y
+	})
+	tree.Put(point{2, 1}, "c")
+	tree.Put(point{1, 2}, "b")
+	tree.Put(point{1, 1}, "a")
+	assert.Equal(t, []point{{1, 1}, {1, 2}, {2, 1}}, tree.AllValues())
+	assert.Equal(t, []string{"a", "b", "c"}, tree.Values())
+
+	words := NewAVLTreeFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	words.Put("Go", 1)
+	words.Put("go", 2)
+	assert.Equal(t, []string{"Go"}, words.AllValues())
+	v, _ := words.Get("GO")
+	assert.Equal(t, 2, v)
+}
+
+func TestAVLTreeRankSelect(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	assert.Equal(t, 0, tree.Len())
+	_, _, ok := tree.Select(0)
+	assert.False(t, ok)
+
+	for k := 0; k < maxNum; k++ {
+		tree.Put(k*2, k)
+	}
+	assert.Equal(t, maxNum, tree.Len())
+	for i := 0; i < maxNum; i++ {
+		assert.Equal(t, i, tree.Rank(i*2))
+		assert.Equal(t, i+1, tree.Rank(i*2+1))
+		k, v, ok := tree.Select(i)
+		assert.True(t, ok)
+		assert.Equal(t, i*2, k)
+		assert.Equal(t, i, v)
+	}
+	assert.Equal(t, 0, tree.Rank(-1))
+	_, _, ok = tree.Select(maxNum)
+	assert.False(t, ok)
+	_, _, ok = tree.Select(-1)
+	assert.False(t, ok)
+
+	k, _, ok := tree.Kth(1)
+	assert.True(t, ok)
+	assert.Equal(t, 0, k)
+	_, _, ok = tree.Kth(0)
+	assert.False(t, ok)
+
+	for i := 0; i < maxNum; i += 2 {
+		assert.True(t, tree.Delete(i*2))
+	}
+	assert.Equal(t, maxNum/2, tree.Len())
+	for i := 0; i < maxNum/2; i++ {
+		k, _, _ := tree.Select(i)
+		assert.Equal(t, (2*i+1)*2, k)
+		assert.Equal(t, i, tree.Rank(k))
+	}
+}
+
+func TestAVLTreeNearest(t *testing.T) {
+	tree := NewAVLTree[int, string]()
+	k, _, ok := tree.Floor(1)
+	assert.False(t, ok)
+	assert.Equal(t, 0, k)
+
+	for i := 0; i < maxNum; i++ {
+		tree.Put(i*10, strconv.Itoa(i))
+	}
+	cases := []struct {
+		fn       func(int) (int, string, bool)
+		in, want int
+		ok       bool
+	}{
+		{tree.Floor, 55, 50, true},
+		{tree.Floor, 50, 50, true},
+		{tree.Floor, -1, 0, false},
+		{tree.Ceiling, 55, 60, true},
+		{tree.Ceiling, 60, 60, true},
+		{tree.Ceiling, maxNum*10 - 9, 0, false},
+		{tree.Lower, 50, 40, true},
+		{tree.Lower, 51, 50, true},
+		{tree.Lower, 0, 0, false},
+		{tree.Higher, 50, 60, true},
+		{tree.Higher, 49, 50, true},
+		{tree.Higher, (maxNum - 1) * 10, 0, false},
+	}
+	for _, c := range cases {
+		k, v, ok := c.fn(c.in)
+		assert.Equal(t, c.ok, ok)
+		assert.Equal(t, c.want, k)
+		if ok {
+			assert.Equal(t, strconv.Itoa(c.want/10), v)
+		}
+	}
+}
+
+func TestAVLTreeRange(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	for i := 0; i < maxNum; i++ {
+		tree.Put(i, i*i)
+	}
+	collect := func(lo, hi int, bound RangeBound) []int {
+		keys := make([]int, 0)
+		tree.Range(lo, hi, bound, func(k, v int) bool {
+			assert.Equal(t, k*k, v)
+			keys = append(keys, k)
+			return true
+		})
+		return keys
+	}
+	assert.Equal(t, []int{10, 11, 12}, collect(10, 12, IncludeBoth))
+	assert.Equal(t, []int{11}, collect(10, 12, 0))
+	assert.Equal(t, []int{10, 11}, collect(10, 12, IncludeLo))
+	assert.Equal(t, []int{11, 12}, collect(10, 12, IncludeHi))
+	assert.Equal(t, []int{0, 1}, collect(-5, 1, IncludeBoth))
+	assert.Equal(t, []int{98, 99}, collect(98, maxNum*2, IncludeBoth))
+	assert.Equal(t, []int{}, collect(12, 10, IncludeBoth))
+	assert.Equal(t, []int{}, collect(10, 10, IncludeLo))
+	assert.Equal(t, maxNum, len(collect(-1, maxNum, 0)))
+
+	n := 0
+	tree.Range(0, maxNum, IncludeBoth, func(k, v int) bool {
+		n++
+		return k < 4
+	})
+	assert.Equal(t, 5, n)
+}
+
+func TestAVLTreeAscendDescend(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	for range tree.Ascend() {
+		t.Fatal("empty tree should not yield")
+	}
+	keys := rand.Perm(maxNum)
+	for _, k := range keys {
+		tree.Put(k, -k)
+	}
+
+	i := 0
+	for k, v := range tree.Ascend() {
+		assert.Equal(t, i, k)
+		assert.Equal(t, -i, v)
+		i++
+	}
+	assert.Equal(t, maxNum, i)
+
+	i = maxNum - 1
+	for k := range tree.Descend() {
+		assert.Equal(t, i, k)
+		if k == maxNum/2 {
+			break
+		}
+		i--
+	}
+	assert.Equal(t, maxNum/2, i)
+}
+
+func TestAVLMultiTree(t *testing.T) {
+	tree := NewAVLMultiTree[int, struct{}]()
+	latencies := []int{5, 3, 5, 1, 3, 5, 8}
+	for _, v := range latencies {
+		tree.Insert(v)
+	}
+	assert.Equal(t, []int{1, 3, 3, 5, 5, 5, 8}, tree.AllValues())
+	assert.Equal(t, 7, tree.Len())
+	assert.Equal(t, 3, tree.Count(5))
+	assert.Equal(t, 0, tree.Count(4))
+	assert.Equal(t, 3, tree.Rank(5))
+	assert.Equal(t, 6, tree.Rank(8))
+	for i, want := range []int{1, 3, 3, 5, 5, 5, 8} {
+		k, _, ok := tree.Select(i)
+		assert.True(t, ok)
+		assert.Equal(t, want, k)
+	}
+
+	n := 0
+	for range tree.Ascend() {
+		n++
+	}
+	assert.Equal(t, 7, n)
+	keys := make([]int, 0)
+	tree.Range(3, 5, IncludeBoth, func(k int, _ struct{}) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, []int{3, 3, 5, 5, 5}, keys)
+
+	assert.True(t, tree.DeleteOne(5))
+	assert.Equal(t, 2, tree.Count(5))
+	assert.Equal(t, 6, tree.Len())
+	assert.Equal(t, 2, tree.DeleteAll(3))
+	assert.Equal(t, 0, tree.DeleteAll(3))
+	assert.False(t, tree.DeleteOne(3))
+	assert.Equal(t, []int{1, 5, 5, 8}, tree.AllValues())
+	assert.Equal(t, 1, tree.Rank(5))
+	assert.True(t, tree.Delete(5))
+	assert.Equal(t, []int{1, 8}, tree.AllValues())
+	assert.Equal(t, 2, tree.Len())
+}
+
+func TestAVLMultiTreeSharedValue(t *testing.T) {
+	// 相同的键共享一个值，重复 Put 时计数增加，值以最后一次为准
+	tree := NewAVLMultiTree[string, int]()
+	tree.Put("a", 1)
+	tree.Put("b", 2)
+	tree.Put("a", 3)
+	assert.Equal(t, 2, tree.Count("a"))
+	assert.Equal(t, []string{"a", "a", "b"}, tree.AllValues())
+	assert.Equal(t, []int{3, 3, 2}, tree.Values())
+	v, _ := tree.Get("a")
+	assert.Equal(t, 3, v)
+	assert.True(t, tree.DeleteOne("a"))
+	assert.Equal(t, []int{3, 2}, tree.Values())
+}
+
+func TestAVLTreeDeleteOne(t *testing.T) {
+	tree := NewAVLTree[int, struct{}]()
+	tree.Insert(1)
+	tree.Insert(1)
+	assert.Equal(t, 1, tree.Count(1))
+	assert.Equal(t, 1, tree.Len())
+	assert.True(t, tree.DeleteOne(1))
+	assert.Equal(t, 0, tree.Count(1))
+	assert.Equal(t, 0, tree.Len())
+}
+
+func TestAVLTreeValidate(t *testing.T) {
+	tree := NewAVLTree[int, struct{}]()
+	assert.Nil(t, tree.Validate())
+	for i := 0; i < maxNum; i++ {
+		tree.Insert(i)
+		assert.Nil(t, tree.Validate())
+	}
+	// 删除时的旋转需要依据子树高度而不是被删除的键
+	for i := maxNum - 1; i >= 0; i -= 3 {
+		tree.Delete(i)
+		assert.Nil(t, tree.Validate())
+	}
+
+	tree.tree.h++
+	assert.NotNil(t, tree.Validate())
+	tree.tree.h--
+	tree.tree.left.key, tree.tree.right.key = tree.tree.right.key, tree.tree.left.key
+	assert.NotNil(t, tree.Validate())
+}
+
+// 随机插入删除序列，与有序切片的结果进行对比
+// go test -fuzz=FuzzAVLTree
+func FuzzAVLTree(f *testing.F) {
+	f.Add([]byte{0, 2, 4, 6, 8, 1, 3, 5}, false)
+	f.Add([]byte{10, 8, 6, 4, 2, 0, 7, 11}, true)
+	f.Add([]byte{2, 2, 2, 4, 4, 3, 3, 5, 5}, true)
+	f.Add([]byte{6, 2, 4, 0xfe, 8, 0xff, 0xff, 0xfe}, false)
+	f.Fuzz(func(t *testing.T, ops []byte, multi bool) {
+		tree := NewAVLTree[int, int]()
+		if multi {
+			tree = NewAVLMultiTree[int, int]()
+		}
+		oracle := make([]int, 0)
+		for _, op := range ops {
+			k := int(op >> 1)
+			i := sort.SearchInts(oracle, k)
+			found := i < len(oracle) && oracle[i] == k
+			if op == 0xff && len(oracle) > 0 {
+				k, _, _ := tree.PopMax()
+				assert.Equal(t, oracle[len(oracle)-1], k)
+				oracle = oracle[:len(oracle)-1]
+			} else if op == 0xfe && len(oracle) > 0 {
+				k, _, _ := tree.PopMin()
+				assert.Equal(t, oracle[0], k)
+				oracle = oracle[1:]
+			} else if op&1 == 0 {
+				tree.Insert(k)
+				if !found || multi {
+					oracle = append(oracle[:i], append([]int{k}, oracle[i:]...)...)
+				}
+			} else {
+				assert.Equal(t, found, tree.DeleteOne(k))
+				if found {
+					oracle = append(oracle[:i], oracle[i+1:]...)
+				}
+			}
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("%v\n%s", err, tree)
+			}
+			assert.Equal(t, len(oracle), tree.Len())
+		}
+		assert.Equal(t, oracle, tree.AllValues())
+	})
+}
+
+func TestAVLTreeFromSorted(t *testing.T) {
+	keys := make([]int, 0)
+	for i := 0; i < maxNum; i++ {
+		keys = append(keys, i, i)
+	}
+	tree := NewAVLTreeFromSorted[int, string](keys)
+	assert.Nil(t, tree.Validate())
+	assert.Equal(t, maxNum, tree.Len())
+	assert.True(t, assertSort(tree.AllValues()))
+	tree.Put(maxNum, "x")
+	assert.True(t, tree.Delete(0))
+	assert.Nil(t, tree.Validate())
+
+	empty := NewAVLTreeFromSorted[int, string](nil)
+	assert.Equal(t, 0, empty.Len())
+	empty.Insert(1)
+	assert.Equal(t, []int{1}, empty.AllValues())
+
+	assert.Panics(t, func() { NewAVLTreeFromSorted[int, string]([]int{2, 1}) })
+}
+
+func TestAVLTreeBuildFrom(t *testing.T) {
+	keys := yieldRandomArray(maxCnt)
+	tree := NewAVLTree[int, struct{}]()
+	tree.Insert(-1)
+	tree.BuildFrom(keys)
+	assert.Nil(t, tree.Validate())
+	assert.False(t, tree.Search(-1))
+	assert.True(t, assertSort(tree.AllValues()))
+	for _, k := range keys {
+		assert.True(t, tree.Search(k))
+	}
+
+	multi := NewAVLMultiTree[int, struct{}]()
+	multi.BuildFrom([]int{3, 1, 3, 2, 1, 3})
+	assert.Nil(t, multi.Validate())
+	assert.Equal(t, []int{1, 1, 2, 3, 3, 3}, multi.AllValues())
+	assert.Equal(t, 3, multi.Count(3))
+}
+
+func TestAVLTreeFromPairs(t *testing.T) {
+	tree := NewAVLTreeFromSortedPairs([]int{1, 2, 2, 3}, []string{"a", "b", "b2", "c"})
+	assert.Nil(t, tree.Validate())
+	assert.Equal(t, []int{1, 2, 3}, tree.AllValues())
+	assert.Equal(t, []string{"a", "b2", "c"}, tree.Values())
+	assert.Panics(t, func() { NewAVLTreeFromSortedPairs([]int{1}, []string{}) })
+	assert.Panics(t, func() { NewAVLTreeFromSortedPairs([]int{2, 1}, []string{"b", "a"}) })
+
+	// 排序是稳定的，相同的键以最后出现的值为准
+	tree.Insert(-1)
+	tree.BuildFromPairs([]int{3, 1, 3, 2}, []string{"c", "a", "c2", "b"})
+	assert.Nil(t, tree.Validate())
+	assert.False(t, tree.Search(-1))
+	assert.Equal(t, []int{1, 2, 3}, tree.AllValues())
+	assert.Equal(t, []string{"a", "b", "c2"}, tree.Values())
+
+	keys := yieldRandomArray(maxCnt)
+	values := make([]int, len(keys))
+	for i, k := range keys {
+		values[i] = -k
+	}
+	big := NewAVLTree[int, int]()
+	big.BuildFromPairs(keys, values)
+	assert.Nil(t, big.Validate())
+	for _, k := range keys {
+		v, ok := big.Get(k)
+		assert.True(t, ok)
+		assert.Equal(t, -k, v)
+	}
+}
+
+func genAVL(n int) *AVLTree[int, struct{}] {
+	t := NewAVLTree[int, struct{}]()
 	for i := 0; i < n; i++ {
 		t.Insert(rand.Int())
 	}
@@ -51,5 +519,16 @@ func BenchmarkAVLInsert10e5(b *testing.B) { genAVL(10e5) }
 
 var at = genAVL(10e5)
 
+func BenchmarkAVLFromSorted10e5(b *testing.B) {
+	keys := make([]int, 10e5)
+	for i := range keys {
+		keys[i] = i
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewAVLTreeFromSorted[int, struct{}](keys)
+	}
+}
+
 func BenchmarkAVLSearch(b *testing.B) { at.Search(rand.Int()) }
 func BenchmarkAVLDelete(b *testing.B) { at.Delete(rand.Int()) }
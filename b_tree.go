@@ -0,0 +1,526 @@
+package collections
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+	"sync/atomic"
+)
+
+type btreeNode[K, V any] struct {
+	keys     []K
+	values   []V
+	children []*btreeNode[K, V] // 叶子节点为空
+	gen      uint64             // 创建节点的树版本，只有版本相同的树可以原地修改该节点
+}
+
+// BTree 内存 B 树，每个节点连续存放多个键，减少指针跳转，适合存放大量键
+// 非根节点的键数在 [degree-1, 2*degree-1] 之间
+type BTree[K, V any] struct {
+	root   *btreeNode[K, V]
+	n      int
+	degree int
+	cmp    func(a, b K) int
+	gen    uint64
+}
+
+// 全局递增的 B 树版本号
+var btreeGen atomic.Uint64
+
+// 生成 B 树，degree 为最小度数，需不小于 2，键类型需满足 cmp.Ordered
+func NewBTree[K cmp.Ordered, V any](degree int) *BTree[K, V] {
+	return NewBTreeFunc[K, V](degree, cmp.Compare[K])
+}
+
+// 使用自定义比较函数生成 B 树
+func NewBTreeFunc[K, V any](degree int, cmp func(a, b K) int) *BTree[K, V] {
+	if degree < 2 {
+		panic("collections: btree degree must be at least 2")
+	}
+	return &BTree[K, V]{degree: degree, cmp: cmp, gen: btreeGen.Add(1)}
+}
+
+// 以 O(1) 生成写时复制的副本，之后两棵树的修改互不影响，只复制修改路径上的节点
+func (b *BTree[K, V]) Clone() *BTree[K, V] {
+	c := *b
+	// 两棵树都更换版本号，原有节点都视为共享节点
+	c.gen = btreeGen.Add(1)
+	b.gen = btreeGen.Add(1)
+	return &c
+}
+
+func (b *BTree[K, V]) maxKeys() int { return 2*b.degree - 1 }
+func (b *BTree[K, V]) minKeys() int { return b.degree - 1 }
+
+// 插入或更新键值对
+func (b *BTree[K, V]) Put(k K, v V) {
+	if b.root == nil {
+		b.root = b.newNode()
+		b.root.keys = append(b.root.keys, k)
+		b.root.values = append(b.root.values, v)
+		b.n++
+		return
+	}
+	b.root = b.mut(b.root)
+	if len(b.root.keys) >= b.maxKeys() {
+		// 根节点已满时先分裂，树高加一
+		mk, mv, right := b.split(b.root, b.maxKeys()/2)
+		left := b.root
+		b.root = b.newNode()
+		b.root.keys = append(b.root.keys, mk)
+		b.root.values = append(b.root.values, mv)
+		b.root.children = append(b.root.children, left, right)
+	}
+	if b.insert(b.root, k, v) {
+		b.n++
+	}
+}
+
+// 获取键对应的值
+func (b *BTree[K, V]) Get(k K) (V, bool) {
+	for t := b.root; t != nil; {
+		i, found := b.find(t, k)
+		if found {
+			return t.values[i], true
+		}
+		if t.leaf() {
+			break
+		}
+		t = t.children[i]
+	}
+	var zero V
+	return zero, false
+}
+
+// 判断键是否存在
+func (b *BTree[K, V]) Contains(k K) bool {
+	_, ok := b.Get(k)
+	return ok
+}
+
+// 插入节点，值为 V 的零值
+func (b *BTree[K, V]) Insert(k K) {
+	var zero V
+	b.Put(k, zero)
+}
+
+// 搜索节点
+func (b *BTree[K, V]) Search(k K) bool {
+	return b.Contains(k)
+}
+
+// 删除节点
+func (b *BTree[K, V]) Delete(k K) bool {
+	if !b.Contains(k) {
+		return false
+	}
+	b.root = b.mut(b.root)
+	b.remove(b.root, k, removeKey)
+	b.shrink()
+	return true
+}
+
+// 返回最小的键值对
+func (b *BTree[K, V]) Min() (K, V, bool) {
+	t := b.root
+	if t == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for !t.leaf() {
+		t = t.children[0]
+	}
+	return t.keys[0], t.values[0], true
+}
+
+// 返回最大的键值对
+func (b *BTree[K, V]) Max() (K, V, bool) {
+	t := b.root
+	if t == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for !t.leaf() {
+		t = t.children[len(t.children)-1]
+	}
+	return t.keys[len(t.keys)-1], t.values[len(t.values)-1], true
+}
+
+// 获取所有节点中的最小键，空树返回 false
+func (b *BTree[K, V]) GetMinValue() (K, bool) {
+	k, _, ok := b.Min()
+	return k, ok
+}
+
+// 获取所有节点中的最大键，空树返回 false
+func (b *BTree[K, V]) GetMaxValue() (K, bool) {
+	k, _, ok := b.Max()
+	return k, ok
+}
+
+// 弹出最小的键值对
+func (b *BTree[K, V]) PopMin() (K, V, bool) {
+	return b.pop(removeMin)
+}
+
+// 弹出最大的键值对
+func (b *BTree[K, V]) PopMax() (K, V, bool) {
+	return b.pop(removeMax)
+}
+
+func (b *BTree[K, V]) pop(typ removeType) (K, V, bool) {
+	if b.root == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	var zero K
+	b.root = b.mut(b.root)
+	k, v := b.remove(b.root, zero, typ)
+	b.shrink()
+	return k, v, true
+}
+
+// 返回排序后所有键
+func (b *BTree[K, V]) AllValues() []K {
+	keys := make([]K, 0, b.n)
+	for k := range b.Ascend() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// 按键的顺序返回所有值
+func (b *BTree[K, V]) Values() []V {
+	values := make([]V, 0, b.n)
+	for _, v := range b.Ascend() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// 键值对数量
+func (b *BTree[K, V]) Len() int {
+	return b.n
+}
+
+// 按键升序遍历 [lo, hi] 区间内的键值对，bound 决定是否包含边界，fn 返回 false 时停止遍历
+func (b *BTree[K, V]) Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool) {
+	if b.root != nil {
+		b.ascendRange(b.root, lo, hi, bound, fn)
+	}
+}
+
+// 返回按键升序遍历的迭代器
+func (b *BTree[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if b.root != nil {
+			b.root.ascend(yield)
+		}
+	}
+}
+
+// 返回按键降序遍历的迭代器
+func (b *BTree[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if b.root != nil {
+			b.root.descend(yield)
+		}
+	}
+}
+
+// 检查每个节点的键数、键的顺序以及所有叶子节点是否在同一层
+func (b *BTree[K, V]) Validate() error {
+	if b.root == nil {
+		if b.n != 0 {
+			return fmt.Errorf("collections: empty tree stores size %d", b.n)
+		}
+		return nil
+	}
+	_, n, err := b.validate(b.root, nil, nil, true)
+	if err != nil {
+		return err
+	}
+	if n != b.n {
+		return fmt.Errorf("collections: tree stores size %d, want %d", b.n, n)
+	}
+	return nil
+}
+
+// 校验以 t 为根的子树，lo 和 hi 为祖先节点限定的开区间边界，返回子树高度和键数
+func (b *BTree[K, V]) validate(t *btreeNode[K, V], lo, hi *K, root bool) (int, int, error) {
+	if len(t.keys) > b.maxKeys() || (!root && len(t.keys) < b.minKeys()) || len(t.keys) == 0 {
+		return 0, 0, fmt.Errorf("collections: node %v has %d keys", t.keys, len(t.keys))
+	}
+	if len(t.values) != len(t.keys) {
+		return 0, 0, fmt.Errorf("collections: node %v has %d values", t.keys, len(t.values))
+	}
+	for i, k := range t.keys {
+		if (i > 0 && b.cmp(t.keys[i-1], k) >= 0) || (lo != nil && b.cmp(k, *lo) <= 0) || (hi != nil && b.cmp(k, *hi) >= 0) {
+			return 0, 0, fmt.Errorf("collections: node %v is out of order", t.keys)
+		}
+	}
+	if t.leaf() {
+		return 0, len(t.keys), nil
+	}
+	if len(t.children) != len(t.keys)+1 {
+		return 0, 0, fmt.Errorf("collections: node %v has %d children", t.keys, len(t.children))
+	}
+	h, n := -1, len(t.keys)
+	for i, child := range t.children {
+		clo, chi := lo, hi
+		if i > 0 {
+			clo = &t.keys[i-1]
+		}
+		if i < len(t.keys) {
+			chi = &t.keys[i]
+		}
+		ch, cn, err := b.validate(child, clo, chi, false)
+		if err != nil {
+			return 0, 0, err
+		}
+		if h >= 0 && ch != h {
+			return 0, 0, fmt.Errorf("collections: leaves under %v are at different depths", t.keys)
+		}
+		h = ch
+		n += cn
+	}
+	return h + 1, n, nil
+}
+
+func (b *BTree[K, V]) newNode() *btreeNode[K, V] {
+	return &btreeNode[K, V]{
+		keys:   make([]K, 0, b.maxKeys()),
+		values: make([]V, 0, b.maxKeys()),
+		gen:    b.gen,
+	}
+}
+
+// 返回可以原地修改的节点，节点属于其他版本时复制一份，避免影响共享该节点的副本
+func (b *BTree[K, V]) mut(t *btreeNode[K, V]) *btreeNode[K, V] {
+	if t.gen == b.gen {
+		return t
+	}
+	node := b.newNode()
+	node.keys = append(node.keys, t.keys...)
+	node.values = append(node.values, t.values...)
+	if !t.leaf() {
+		node.children = append(make([]*btreeNode[K, V], 0, b.maxKeys()+1), t.children...)
+	}
+	return node
+}
+
+// 二分查找第一个不小于 k 的键的位置
+func (b *BTree[K, V]) find(t *btreeNode[K, V], k K) (int, bool) {
+	i := sort.Search(len(t.keys), func(i int) bool {
+		return b.cmp(t.keys[i], k) >= 0
+	})
+	return i, i < len(t.keys) && b.cmp(t.keys[i], k) == 0
+}
+
+// 在第 i 个键处将节点一分为二，返回中间的键值对和右半部分节点
+func (b *BTree[K, V]) split(t *btreeNode[K, V], i int) (K, V, *btreeNode[K, V]) {
+	k, v := t.keys[i], t.values[i]
+	right := b.newNode()
+	right.keys = append(right.keys, t.keys[i+1:]...)
+	right.values = append(right.values, t.values[i+1:]...)
+	clear(t.keys[i:])
+	clear(t.values[i:])
+	t.keys, t.values = t.keys[:i], t.values[:i]
+	if !t.leaf() {
+		right.children = append(make([]*btreeNode[K, V], 0, b.maxKeys()+1), t.children[i+1:]...)
+		clear(t.children[i+1:])
+		t.children = t.children[:i+1]
+	}
+	return k, v, right
+}
+
+// 向未满的节点 t 中插入键值对，键已存在时更新值并返回 false
+func (b *BTree[K, V]) insert(t *btreeNode[K, V], k K, v V) bool {
+	i, found := b.find(t, k)
+	if found {
+		t.values[i] = v
+		return false
+	}
+	if t.leaf() {
+		t.keys = slices.Insert(t.keys, i, k)
+		t.values = slices.Insert(t.values, i, v)
+		return true
+	}
+	child := b.mut(t.children[i])
+	t.children[i] = child
+	if len(child.keys) >= b.maxKeys() {
+		// 子节点已满时先分裂，保证向下插入时不需要回溯
+		mk, mv, right := b.split(child, b.maxKeys()/2)
+		t.keys = slices.Insert(t.keys, i, mk)
+		t.values = slices.Insert(t.values, i, mv)
+		t.children = slices.Insert(t.children, i+1, right)
+		switch cmp := b.cmp(k, mk); {
+		case cmp == 0:
+			t.values[i] = v
+			return false
+		case cmp > 0:
+			i++
+		}
+	}
+	return b.insert(t.children[i], k, v)
+}
+
+type removeType uint8
+
+const (
+	removeKey removeType = iota // 删除指定的键
+	removeMin                   // 删除最小的键
+	removeMax                   // 删除最大的键
+)
+
+// 从可修改的节点 t 的子树中删除键并返回被删除的键值对，removeKey 时需保证 k 存在
+// 向下查找前保证子节点至少有 degree 个键，删除后不需要回溯调整
+func (b *BTree[K, V]) remove(t *btreeNode[K, V], k K, typ removeType) (K, V) {
+	var i int
+	var found bool
+	switch typ {
+	case removeMin:
+		if t.leaf() {
+			return t.removeAt(0)
+		}
+	case removeMax:
+		if t.leaf() {
+			return t.removeAt(len(t.keys) - 1)
+		}
+		i = len(t.keys)
+	default:
+		i, found = b.find(t, k)
+		if t.leaf() {
+			return t.removeAt(i)
+		}
+	}
+	if len(t.children[i].keys) <= b.minKeys() {
+		b.grow(t, i)
+		return b.remove(t, k, typ)
+	}
+	child := b.mut(t.children[i])
+	t.children[i] = child
+	if found {
+		// 内部节点中的键使用左子树中的最大键取代
+		k, v := t.keys[i], t.values[i]
+		t.keys[i], t.values[i] = b.remove(child, k, removeMax)
+		return k, v
+	}
+	return b.remove(child, k, typ)
+}
+
+// 第 i 个子节点的键数不足时，从相邻的兄弟节点借一个键，兄弟节点也不足时与其合并
+func (b *BTree[K, V]) grow(t *btreeNode[K, V], i int) {
+	if i > 0 && len(t.children[i-1].keys) > b.minKeys() {
+		// 从左兄弟借：父节点的键下移到子节点头部，左兄弟的最大键上移
+		child, left := b.mut(t.children[i]), b.mut(t.children[i-1])
+		t.children[i], t.children[i-1] = child, left
+		child.keys = slices.Insert(child.keys, 0, t.keys[i-1])
+		child.values = slices.Insert(child.values, 0, t.values[i-1])
+		t.keys[i-1], t.values[i-1] = left.removeAt(len(left.keys) - 1)
+		if !left.leaf() {
+			child.children = slices.Insert(child.children, 0, left.children[len(left.children)-1])
+			left.children = slices.Delete(left.children, len(left.children)-1, len(left.children))
+		}
+	} else if i < len(t.keys) && len(t.children[i+1].keys) > b.minKeys() {
+		// 从右兄弟借：父节点的键下移到子节点尾部，右兄弟的最小键上移
+		child, right := b.mut(t.children[i]), b.mut(t.children[i+1])
+		t.children[i], t.children[i+1] = child, right
+		child.keys = append(child.keys, t.keys[i])
+		child.values = append(child.values, t.values[i])
+		t.keys[i], t.values[i] = right.removeAt(0)
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = slices.Delete(right.children, 0, 1)
+		}
+	} else {
+		// 与右兄弟合并，最后一个子节点则与左兄弟合并
+		if i >= len(t.keys) {
+			i--
+		}
+		child, right := b.mut(t.children[i]), t.children[i+1]
+		t.children[i] = child
+		k, v := t.removeAt(i)
+		child.keys = append(append(child.keys, k), right.keys...)
+		child.values = append(append(child.values, v), right.values...)
+		child.children = append(child.children, right.children...)
+		t.children = slices.Delete(t.children, i+1, i+2)
+	}
+}
+
+// 删除后元素数减一，根节点为空时树高减一
+func (b *BTree[K, V]) shrink() {
+	b.n--
+	if len(b.root.keys) == 0 {
+		if b.root.leaf() {
+			b.root = nil
+		} else {
+			b.root = b.root.children[0]
+		}
+	}
+}
+
+// 按键升序遍历子树中满足区间的键值对，返回 false 表示停止遍历
+func (b *BTree[K, V]) ascendRange(t *btreeNode[K, V], lo, hi K, bound RangeBound, fn func(k K, v V) bool) bool {
+	i := sort.Search(len(t.keys), func(i int) bool {
+		cmp := b.cmp(t.keys[i], lo)
+		return cmp > 0 || (cmp == 0 && bound&IncludeLo != 0)
+	})
+	for ; i <= len(t.keys); i++ {
+		if !t.leaf() && !b.ascendRange(t.children[i], lo, hi, bound, fn) {
+			return false
+		}
+		if i == len(t.keys) {
+			break
+		}
+		cmp := b.cmp(t.keys[i], hi)
+		if cmp > 0 || (cmp == 0 && bound&IncludeHi == 0) {
+			return false
+		}
+		if !fn(t.keys[i], t.values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *btreeNode[K, V]) leaf() bool {
+	return len(t.children) == 0
+}
+
+// 删除第 i 个键值对并返回
+func (t *btreeNode[K, V]) removeAt(i int) (K, V) {
+	k, v := t.keys[i], t.values[i]
+	t.keys = slices.Delete(t.keys, i, i+1)
+	t.values = slices.Delete(t.values, i, i+1)
+	return k, v
+}
+
+func (t *btreeNode[K, V]) ascend(yield func(K, V) bool) bool {
+	for i := range t.keys {
+		if !t.leaf() && !t.children[i].ascend(yield) {
+			return false
+		}
+		if !yield(t.keys[i], t.values[i]) {
+			return false
+		}
+	}
+	return t.leaf() || t.children[len(t.keys)].ascend(yield)
+}
+
+func (t *btreeNode[K, V]) descend(yield func(K, V) bool) bool {
+	for i := len(t.keys) - 1; i >= 0; i-- {
+		if !t.leaf() && !t.children[i+1].descend(yield) {
+			return false
+		}
+		if !yield(t.keys[i], t.values[i]) {
+			return false
+		}
+	}
+	return t.leaf() || t.children[0].descend(yield)
+}
@@ -0,0 +1,180 @@
+package collections
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBTree(t *testing.T) {
+	tree := NewBTree[int, struct{}](2)
+	for i := 0; i < maxNum; i++ {
+		tree.Insert(i)
+		tree.Insert(maxNum + i)
+	}
+	assert.NoError(t, tree.Validate())
+	assert.Equal(t, len(tree.AllValues()), maxNum*2)
+	assert.True(t, assertSort(tree.AllValues()))
+	maxKey, ok := tree.GetMaxValue()
+	assert.True(t, ok)
+	assert.Equal(t, maxKey, 2*maxNum-1)
+	minKey, ok := tree.GetMinValue()
+	assert.True(t, ok)
+	assert.Equal(t, minKey, 0)
+	assert.True(t, tree.Search(50))
+	assert.False(t, tree.Search(-10))
+	assert.False(t, tree.Delete(-10))
+	assert.True(t, tree.Delete(10))
+	assert.NoError(t, tree.Validate())
+	assert.Equal(t, len(tree.AllValues()), maxNum*2-1)
+}
+
+func TestBTreeEmpty(t *testing.T) {
+	assert.Panics(t, func() { NewBTree[int, int](1) })
+
+	tree := NewBTree[int, string](3)
+	assert.False(t, tree.Delete(0))
+	_, _, ok := tree.Min()
+	assert.False(t, ok)
+	_, _, ok = tree.Max()
+	assert.False(t, ok)
+	_, _, ok = tree.PopMin()
+	assert.False(t, ok)
+	assert.Equal(t, 0, len(tree.AllValues()))
+
+	tree.Put(1, "a")
+	tree.Put(1, "b")
+	assert.Equal(t, 1, tree.Len())
+	v, ok := tree.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "b", v)
+	assert.True(t, tree.Delete(1))
+	assert.Nil(t, tree.root)
+	assert.NoError(t, tree.Validate())
+}
+
+func TestBTreeRandom(t *testing.T) {
+	for _, degree := range []int{2, 3, 4, 16} {
+		tree := NewBTree[int, int](degree)
+		oracle := make(map[int]int)
+		for i := 0; i < 4*nums; i++ {
+			k := rand.Intn(nums)
+			if rand.Intn(3) == 0 {
+				_, ok := oracle[k]
+				assert.Equal(t, ok, tree.Delete(k))
+				delete(oracle, k)
+			} else {
+				tree.Put(k, i)
+				oracle[k] = i
+			}
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("degree %d: %v", degree, err)
+			}
+		}
+		assert.Equal(t, len(oracle), tree.Len())
+		for k, v := range oracle {
+			got, ok := tree.Get(k)
+			assert.True(t, ok)
+			assert.Equal(t, v, got)
+		}
+		keys := tree.AllValues()
+		assert.True(t, assertSort(keys))
+		for len(keys) > 0 {
+			k, v, ok := tree.PopMax()
+			assert.True(t, ok)
+			assert.Equal(t, keys[len(keys)-1], k)
+			assert.Equal(t, oracle[k], v)
+			keys = keys[:len(keys)-1]
+			if len(keys) > 0 {
+				k, _, _ = tree.PopMin()
+				assert.Equal(t, keys[0], k)
+				keys = keys[1:]
+			}
+			assert.NoError(t, tree.Validate())
+		}
+		assert.Equal(t, 0, tree.Len())
+	}
+}
+
+func TestBTreeRange(t *testing.T) {
+	tree := NewBTree[int, int](3)
+	for i := 0; i < maxNum; i += 2 {
+		tree.Put(i, i*i)
+	}
+	for _, bound := range []RangeBound{0, IncludeLo, IncludeHi, IncludeBoth} {
+		lo, hi := 10, 30
+		want := make([]int, 0)
+		for i := 0; i < maxNum; i += 2 {
+			if (i > lo || (i == lo && bound&IncludeLo != 0)) && (i < hi || (i == hi && bound&IncludeHi != 0)) {
+				want = append(want, i)
+			}
+		}
+		got := make([]int, 0)
+		tree.Range(lo, hi, bound, func(k, v int) bool {
+			assert.Equal(t, k*k, v)
+			got = append(got, k)
+			return true
+		})
+		assert.Equal(t, want, got)
+	}
+
+	n := 0
+	tree.Range(0, maxNum, IncludeBoth, func(k, v int) bool {
+		n++
+		return n < 3
+	})
+	assert.Equal(t, 3, n)
+
+	keys := make([]int, 0)
+	for k := range tree.Descend() {
+		keys = append(keys, k)
+	}
+	assert.True(t, sort.IsSorted(sort.Reverse(sort.IntSlice(keys))))
+	assert.Equal(t, maxNum/2, len(keys))
+}
+
+func TestBTreeClone(t *testing.T) {
+	tree := NewBTree[int, int](2)
+	for i := 0; i < maxNum; i++ {
+		tree.Put(i, i)
+	}
+	clone := tree.Clone()
+	for i := 0; i < maxNum; i += 2 {
+		tree.Delete(i)
+		clone.Put(i, -i)
+	}
+	clone.Put(maxNum, maxNum)
+	assert.NoError(t, tree.Validate())
+	assert.NoError(t, clone.Validate())
+	assert.Equal(t, maxNum/2, tree.Len())
+	assert.Equal(t, maxNum+1, clone.Len())
+	for i := 0; i < maxNum; i++ {
+		v, ok := tree.Get(i)
+		assert.Equal(t, i%2 == 1, ok)
+		if ok {
+			assert.Equal(t, i, v)
+		}
+		v, _ = clone.Get(i)
+		if i%2 == 0 {
+			assert.Equal(t, -i, v)
+		} else {
+			assert.Equal(t, i, v)
+		}
+	}
+}
+
+func genBTree(n int) *BTree[int, struct{}] {
+	tree := NewBTree[int, struct{}](32)
+	for i := 0; i < n; i++ {
+		tree.Insert(rand.Int())
+	}
+	return tree
+}
+
+func BenchmarkBTreeInsert10e4(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		genBTree(10e4)
+	}
+}
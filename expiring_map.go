@@ -0,0 +1,223 @@
+package collections
+
+import (
+	"iter"
+	"sync"
+	"time"
+)
+
+// Clock 提供当前时间和定时器，测试时可以替换为手动推进的时钟
+type Clock interface {
+	Now() time.Time
+	// 返回的 channel 在时间经过 d 之后收到当时的时间
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+type expiringEntry[V any] struct {
+	value    V
+	deadline time.Time // 零值表示永不过期
+}
+
+// ExpiringMap 并发安全、按插入顺序遍历的过期 Map，每个键可以设置独立的存活时间
+// 读操作忽略已过期的键，过期的键由 Purge 或后台清理协程删除
+type ExpiringMap[K comparable, V any] struct {
+	items *OrderedMap[K, expiringEntry[V]]
+	clock Clock
+	// 所有键的过期时间是否按插入顺序递增，此时 Purge 只需从头删除到第一个未过期的键
+	ordered bool
+	mut     *sync.RWMutex
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// 生成使用系统时间的过期 Map
+func NewExpiringMap[K comparable, V any]() *ExpiringMap[K, V] {
+	return NewExpiringMapWithClock[K, V](systemClock{})
+}
+
+// 生成使用指定时钟的过期 Map
+func NewExpiringMapWithClock[K comparable, V any](clock Clock) *ExpiringMap[K, V] {
+	return &ExpiringMap[K, V]{
+		items:   NewOrderedMap[K, expiringEntry[V]](),
+		clock:   clock,
+		ordered: true,
+		mut:     new(sync.RWMutex),
+	}
+}
+
+// 写入键值对，ttl <= 0 表示永不过期
+// 已存在的键会更新过期时间并视为重新插入，排到最后
+func (m *ExpiringMap[K, V]) Set(key K, value V, ttl time.Duration) {
+	defer m.mut.Unlock()
+	m.mut.Lock()
+	e := expiringEntry[V]{value: value}
+	if ttl > 0 {
+		e.deadline = m.clock.Now().Add(ttl)
+	}
+	m.items.Delete(key)
+	if _, last, ok := m.items.Back(); ok {
+		m.ordered = m.ordered && !deadlineAfter(last.deadline, e.deadline)
+	} else {
+		m.ordered = true
+	}
+	m.items.Set(key, e)
+}
+
+// 读取未过期的键对应的值
+func (m *ExpiringMap[K, V]) Get(key K) (V, bool) {
+	defer m.mut.RUnlock()
+	m.mut.RLock()
+	e, ok := m.items.Get(key)
+	if !ok || m.expired(e, m.clock.Now()) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// 判断键是否存在且未过期
+func (m *ExpiringMap[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// 返回键的剩余存活时间，永不过期的键返回 0
+func (m *ExpiringMap[K, V]) TTL(key K) (time.Duration, bool) {
+	defer m.mut.RUnlock()
+	m.mut.RLock()
+	e, ok := m.items.Get(key)
+	now := m.clock.Now()
+	if !ok || m.expired(e, now) {
+		return 0, false
+	}
+	if e.deadline.IsZero() {
+		return 0, true
+	}
+	return e.deadline.Sub(now), true
+}
+
+// 删除键
+func (m *ExpiringMap[K, V]) Delete(key K) bool {
+	defer m.mut.Unlock()
+	m.mut.Lock()
+	return m.items.Delete(key)
+}
+
+// 键值对数量，包含已过期但尚未清理的键
+func (m *ExpiringMap[K, V]) Len() int {
+	defer m.mut.RUnlock()
+	m.mut.RLock()
+	return m.items.Len()
+}
+
+// 按插入顺序遍历所有未过期的键值对，遍历的是调用时的快照，遍历过程中可以修改 Map
+func (m *ExpiringMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mut.RLock()
+		now := m.clock.Now()
+		keys := make([]K, 0, m.items.Len())
+		values := make([]V, 0, m.items.Len())
+		for k, e := range m.items.All() {
+			if !m.expired(e, now) {
+				keys = append(keys, k)
+				values = append(values, e.value)
+			}
+		}
+		m.mut.RUnlock()
+		for i := range keys {
+			if !yield(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// 按插入顺序删除所有已过期的键，返回删除的数量
+// 过期时间按插入顺序递增时（如所有键使用相同的 ttl），只需访问已过期的键和第一个未过期的键
+func (m *ExpiringMap[K, V]) Purge() int {
+	defer m.mut.Unlock()
+	m.mut.Lock()
+	now := m.clock.Now()
+	n := 0
+	if m.ordered {
+		for k, e, ok := m.items.Front(); ok && m.expired(e, now); k, e, ok = m.items.Front() {
+			m.items.Delete(k)
+			n++
+		}
+		return n
+	}
+	// 完整扫描一遍，同时重新判断剩余键的过期时间是否有序
+	m.ordered = true
+	var last time.Time
+	first := true
+	for k, e := range m.items.All() {
+		if m.expired(e, now) {
+			m.items.Delete(k)
+			n++
+			continue
+		}
+		if !first && deadlineAfter(last, e.deadline) {
+			m.ordered = false
+		}
+		last, first = e.deadline, false
+	}
+	return n
+}
+
+// 启动后台协程，每隔 interval 调用一次 Purge，重复调用不会启动多个协程
+// 间隔由 Clock 计时，interval <= 0 时不启动
+func (m *ExpiringMap[K, V]) StartJanitor(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	m.mut.Lock()
+	if m.stop != nil {
+		m.mut.Unlock()
+		return
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	m.stop, m.done = stop, done
+	m.mut.Unlock()
+
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-m.clock.After(interval):
+				m.Purge()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// 停止后台清理协程并等待其退出，未启动时不做任何操作
+func (m *ExpiringMap[K, V]) Close() {
+	m.mut.Lock()
+	stop, done := m.stop, m.done
+	m.stop, m.done = nil, nil
+	m.mut.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+func (m *ExpiringMap[K, V]) expired(e expiringEntry[V], now time.Time) bool {
+	return !e.deadline.IsZero() && !now.Before(e.deadline)
+}
+
+// 判断过期时间 a 是否晚于 b，零值表示永不过期
+func deadlineAfter(a, b time.Time) bool {
+	if a.IsZero() {
+		return !b.IsZero()
+	}
+	return !b.IsZero() && a.After(b)
+}
@@ -0,0 +1,172 @@
+package collections
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now     time.Time
+	mut     sync.Mutex
+	cond    *sync.Cond
+	waiters []fakeTimer
+}
+
+type fakeTimer struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	c := &fakeClock{now: time.Unix(0, 0)}
+	c.cond = sync.NewCond(&c.mut)
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeTimer{deadline: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// 推进时间并触发所有到期的定时器
+func (c *fakeClock) Advance(d time.Duration) {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if c.now.Before(w.deadline) {
+			waiters = append(waiters, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = waiters
+}
+
+// 阻塞直到有 n 个定时器在等待
+func (c *fakeClock) BlockUntil(n int) {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+func TestExpiringMap(t *testing.T) {
+	clock := newFakeClock()
+	m := NewExpiringMapWithClock[string, int](clock)
+	m.Set("a", 1, time.Second)
+	m.Set("b", 2, 3*time.Second)
+	m.Set("forever", 3, 0)
+
+	v, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	ttl, ok := m.TTL("b")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, ttl)
+	ttl, ok = m.TTL("forever")
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), ttl)
+
+	clock.Advance(time.Second)
+	// 过期的键读不到，但在清理前仍然占用空间
+	assert.False(t, m.Has("a"))
+	_, ok = m.TTL("a")
+	assert.False(t, ok)
+	assert.Equal(t, 3, m.Len())
+	keys := make([]string, 0)
+	for k := range m.All() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"b", "forever"}, keys)
+
+	assert.Equal(t, 1, m.Purge())
+	assert.Equal(t, 2, m.Len())
+	clock.Advance(time.Hour)
+	assert.Equal(t, 1, m.Purge())
+	assert.True(t, m.Has("forever"))
+	assert.True(t, m.Delete("forever"))
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestExpiringMapRefresh(t *testing.T) {
+	clock := newFakeClock()
+	m := NewExpiringMapWithClock[int, int](clock)
+	for i := 0; i < maxNum; i++ {
+		m.Set(i, i, time.Minute)
+		clock.Advance(time.Second)
+	}
+	// 重新写入的键排到最后并刷新过期时间
+	m.Set(0, 0, time.Minute)
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, 70, m.Purge())
+	first := -1
+	for k := range m.All() {
+		first = k
+		break
+	}
+	assert.Equal(t, 71, first)
+	assert.True(t, m.Has(0))
+	assert.Equal(t, maxNum-70, m.Len())
+}
+
+func TestExpiringMapMixedTTL(t *testing.T) {
+	clock := newFakeClock()
+	m := NewExpiringMapWithClock[int, int](clock)
+	// 过期时间与插入顺序不一致时 Purge 需要完整扫描
+	for i := 0; i < maxNum; i++ {
+		m.Set(i, i, time.Duration(maxNum-i)*time.Second)
+	}
+	m.Set(maxNum, maxNum, 0)
+	assert.False(t, m.ordered)
+	clock.Advance(maxNum / 2 * time.Second)
+	assert.Equal(t, maxNum/2, m.Purge())
+	for k := range m.All() {
+		assert.True(t, k < maxNum/2 || k == maxNum)
+	}
+	// 剩余键的过期时间仍然无序
+	assert.False(t, m.ordered)
+	clock.Advance(time.Hour)
+	assert.Equal(t, maxNum/2, m.Purge())
+	assert.True(t, m.ordered)
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestExpiringMapJanitor(t *testing.T) {
+	clock := newFakeClock()
+	m := NewExpiringMapWithClock[int, int](clock)
+	for i := 0; i < maxNum; i++ {
+		m.Set(i, i, time.Second)
+	}
+	m.StartJanitor(0)
+	m.StartJanitor(time.Second)
+	m.StartJanitor(time.Second)
+	clock.BlockUntil(1)
+	clock.Advance(time.Second / 2)
+	assert.Equal(t, maxNum, m.Len())
+	// 协程清理完成后才会重新等待下一个间隔
+	clock.Advance(time.Second / 2)
+	clock.BlockUntil(1)
+	assert.Equal(t, 0, m.Len())
+	m.Close()
+	m.Close()
+
+	m.Set(0, 0, time.Second)
+	clock.Advance(time.Second)
+	assert.Equal(t, 1, m.Len())
+}
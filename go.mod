@@ -0,0 +1,14 @@
+module github.com/chenjiandongx/collections
+
+go 1.23
+
+require (
+	github.com/cevaris/ordered_map v0.0.0-20190319150403-3adeae072e73
+	github.com/stretchr/testify v1.10.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
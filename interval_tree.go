@@ -0,0 +1,160 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Interval 闭区间 [Lo, Hi]
+type Interval[T cmp.Ordered] struct {
+	Lo, Hi T
+}
+
+// 判断两个闭区间是否有交集
+func (iv Interval[T]) Overlaps(lo, hi T) bool {
+	return iv.Lo <= hi && lo <= iv.Hi
+}
+
+// 左端点大于右端点时交换两者
+func (iv Interval[T]) normalize() Interval[T] {
+	if iv.Lo > iv.Hi {
+		iv.Lo, iv.Hi = iv.Hi, iv.Lo
+	}
+	return iv
+}
+
+// 区间树节点的值，max 为子树中所有区间右端点的最大值
+type intervalValue[T cmp.Ordered, P any] struct {
+	payloads []P
+	max      T
+}
+
+// IntervalTree 基于 AVL 树实现的区间树
+// 以区间左端点排序，每个节点额外维护子树中区间右端点的最大值，用于在查询时剪枝
+type IntervalTree[T cmp.Ordered, P any] struct {
+	tree *AVLTree[Interval[T], intervalValue[T, P]]
+	n    int
+}
+
+// 生成区间树
+func NewIntervalTree[T cmp.Ordered, P any]() *IntervalTree[T, P] {
+	tree := NewAVLTreeFunc[Interval[T], intervalValue[T, P]](func(a, b Interval[T]) int {
+		if c := cmp.Compare(a.Lo, b.Lo); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Hi, b.Hi)
+	})
+	tree.augment = func(t *avlNode[Interval[T], intervalValue[T, P]]) {
+		t.value.max = t.key.Hi
+		if t.left != nil && t.left.value.max > t.value.max {
+			t.value.max = t.left.value.max
+		}
+		if t.right != nil && t.right.value.max > t.value.max {
+			t.value.max = t.right.value.max
+		}
+	}
+	return &IntervalTree[T, P]{tree: tree}
+}
+
+// 插入区间及其携带的数据，相同的区间可以插入多次
+// 区间的左端点大于右端点时会交换两者
+func (it *IntervalTree[T, P]) Insert(iv Interval[T], payload P) {
+	iv = iv.normalize()
+	v, _ := it.tree.Get(iv)
+	v.payloads = append(v.payloads, payload)
+	it.tree.Put(iv, v)
+	it.n++
+}
+
+// 删除区间及其携带的所有数据，同一区间插入多次时所有数据会一起删除
+// 与 Insert 一致，区间的左端点大于右端点时会交换两者
+func (it *IntervalTree[T, P]) Delete(iv Interval[T]) bool {
+	iv = iv.normalize()
+	v, ok := it.tree.Get(iv)
+	if !ok {
+		return false
+	}
+	it.tree.Delete(iv)
+	it.n -= len(v.payloads)
+	return true
+}
+
+// 删除区间携带的第一个满足 match 的数据，区间不再携带任何数据时删除该区间
+// 与 Insert 一致，区间的左端点大于右端点时会交换两者
+func (it *IntervalTree[T, P]) DeletePayload(iv Interval[T], match func(p P) bool) bool {
+	iv = iv.normalize()
+	v, ok := it.tree.Get(iv)
+	if !ok {
+		return false
+	}
+	for i, p := range v.payloads {
+		if !match(p) {
+			continue
+		}
+		if len(v.payloads) == 1 {
+			it.tree.Delete(iv)
+		} else {
+			// 复制后再修改，避免与树中原有的切片共享底层数组
+			v.payloads = append(v.payloads[:i:i], v.payloads[i+1:]...)
+			it.tree.Put(iv, v)
+		}
+		it.n--
+		return true
+	}
+	return false
+}
+
+// 插入的区间数量，同一区间插入多次时重复计数
+func (it *IntervalTree[T, P]) Len() int {
+	return it.n
+}
+
+// 按左端点升序遍历所有与 [lo, hi] 相交的区间，lo 大于 hi 时会交换两者
+// 每个结果最多带来 O(logn) 次无效访问，k 个结果的时间复杂度为 O(min(n, (k+1)logn))
+func (it *IntervalTree[T, P]) Overlapping(lo, hi T) iter.Seq2[Interval[T], P] {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return func(yield func(Interval[T], P) bool) {
+		overlapping(it.tree.tree, lo, hi, yield)
+	}
+}
+
+// 按左端点升序遍历所有包含 point 的区间
+func (it *IntervalTree[T, P]) Stabbing(point T) iter.Seq2[Interval[T], P] {
+	return it.Overlapping(point, point)
+}
+
+// 按左端点升序遍历所有区间
+func (it *IntervalTree[T, P]) All() iter.Seq2[Interval[T], P] {
+	return func(yield func(Interval[T], P) bool) {
+		for iv, v := range it.tree.Ascend() {
+			for _, p := range v.payloads {
+				if !yield(iv, p) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// 中序遍历子树，子树最大右端点小于 lo 时整棵子树都不相交，左端点大于 hi 时右子树都不相交
+func overlapping[T cmp.Ordered, P any](t *avlNode[Interval[T], intervalValue[T, P]], lo, hi T, yield func(Interval[T], P) bool) bool {
+	if t == nil || t.value.max < lo {
+		return true
+	}
+	if !overlapping(t.left, lo, hi, yield) {
+		return false
+	}
+	if t.key.Lo > hi {
+		return true
+	}
+	if t.key.Hi >= lo {
+		for _, p := range t.value.payloads {
+			if !yield(t.key, p) {
+				return false
+			}
+		}
+	}
+	return overlapping(t.right, lo, hi, yield)
+}
@@ -0,0 +1,131 @@
+package collections
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 检查每个节点维护的子树最大右端点
+func checkIntervalMax[P any](t *testing.T, node *avlNode[Interval[int], intervalValue[int, P]]) int {
+	if node == nil {
+		return -1 << 31
+	}
+	want := max(node.key.Hi, max(checkIntervalMax(t, node.left), checkIntervalMax(t, node.right)))
+	assert.Equal(t, want, node.value.max)
+	return want
+}
+
+func TestIntervalTree(t *testing.T) {
+	it := NewIntervalTree[int, string]()
+	it.Insert(Interval[int]{9, 12}, "c")
+	it.Insert(Interval[int]{1, 3}, "a")
+	it.Insert(Interval[int]{5, 8}, "b")
+	it.Insert(Interval[int]{5, 8}, "b2")
+	it.Insert(Interval[int]{20, 15}, "d")
+	assert.Equal(t, 5, it.Len())
+
+	collect := func(seq func(func(Interval[int], string) bool)) []string {
+		res := make([]string, 0)
+		for _, p := range seq {
+			res = append(res, p)
+		}
+		return res
+	}
+	assert.Equal(t, []string{"b", "b2", "c"}, collect(it.Overlapping(6, 10)))
+	assert.Equal(t, []string{"a"}, collect(it.Stabbing(3)))
+	assert.Equal(t, []string{"d"}, collect(it.Stabbing(15)))
+	assert.Equal(t, []string{}, collect(it.Stabbing(4)))
+	assert.Equal(t, []string{"a", "b", "b2", "c", "d"}, collect(it.All()))
+
+	assert.True(t, it.Delete(Interval[int]{5, 8}))
+	assert.False(t, it.Delete(Interval[int]{5, 8}))
+	assert.Equal(t, 3, it.Len())
+	assert.Equal(t, []string{"c"}, collect(it.Overlapping(6, 10)))
+
+	// 删除时与插入一样交换左右端点
+	assert.True(t, it.Delete(Interval[int]{20, 15}))
+	assert.Equal(t, 2, it.Len())
+	assert.Equal(t, []string{}, collect(it.Stabbing(15)))
+
+	// 查询区间同样交换左右端点
+	assert.Equal(t, []string{"c"}, collect(it.Overlapping(10, 6)))
+}
+
+func TestIntervalTreeDeletePayload(t *testing.T) {
+	it := NewIntervalTree[int, string]()
+	it.Insert(Interval[int]{5, 8}, "a")
+	it.Insert(Interval[int]{5, 8}, "b")
+	it.Insert(Interval[int]{5, 8}, "a")
+	is := func(s string) func(string) bool {
+		return func(p string) bool { return p == s }
+	}
+	collect := func() []string {
+		res := make([]string, 0)
+		for _, p := range it.All() {
+			res = append(res, p)
+		}
+		return res
+	}
+
+	// 每次只删除一个匹配的数据
+	assert.True(t, it.DeletePayload(Interval[int]{8, 5}, is("a")))
+	assert.Equal(t, []string{"b", "a"}, collect())
+	assert.False(t, it.DeletePayload(Interval[int]{5, 8}, is("c")))
+	assert.False(t, it.DeletePayload(Interval[int]{5, 9}, is("a")))
+	assert.Equal(t, 2, it.Len())
+
+	// 最后一个数据被删除时区间也被删除
+	assert.True(t, it.DeletePayload(Interval[int]{5, 8}, is("b")))
+	assert.True(t, it.DeletePayload(Interval[int]{5, 8}, is("a")))
+	assert.Equal(t, 0, it.Len())
+	assert.Equal(t, 0, it.tree.Len())
+	assert.Equal(t, []string{}, collect())
+}
+
+func TestIntervalTreeRandom(t *testing.T) {
+	it := NewIntervalTree[int, int]()
+	intervals := make([]Interval[int], 0)
+	for i := 0; i < nums; i++ {
+		lo := rand.Intn(nums)
+		iv := Interval[int]{lo, lo + rand.Intn(50)}
+		it.Insert(iv, i)
+		intervals = append(intervals, iv)
+	}
+	for i := 0; i < nums/4; i++ {
+		iv := intervals[rand.Intn(len(intervals))]
+		if it.Delete(iv) {
+			remain := intervals[:0]
+			for _, other := range intervals {
+				if other != iv {
+					remain = append(remain, other)
+				}
+			}
+			intervals = remain
+		}
+	}
+	assert.Nil(t, it.tree.Validate())
+	checkIntervalMax(t, it.tree.tree)
+	assert.Equal(t, len(intervals), it.Len())
+
+	for i := 0; i < maxNum; i++ {
+		lo := rand.Intn(nums)
+		hi := lo + rand.Intn(20)
+		want := 0
+		for _, iv := range intervals {
+			if iv.Overlaps(lo, hi) {
+				want++
+			}
+		}
+		got := 0
+		prev := -1
+		for iv := range it.Overlapping(lo, hi) {
+			assert.True(t, iv.Overlaps(lo, hi))
+			assert.True(t, iv.Lo >= prev)
+			prev = iv.Lo
+			got++
+		}
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,249 @@
+package collections
+
+import "sync"
+
+// EvictReason 表示键值对被移出缓存的原因
+type EvictReason uint8
+
+const (
+	EvictCapacity EvictReason = iota // 超出容量或成本预算，淘汰最久未使用的键
+	EvictReplaced                    // Set 覆盖了旧值
+	EvictRemoved                     // 调用 Remove 删除
+	EvictPurged                      // 调用 Purge 清空
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictReplaced:
+		return "replaced"
+	case EvictRemoved:
+		return "removed"
+	case EvictPurged:
+		return "purged"
+	}
+	return "unknown"
+}
+
+// LRUStats 缓存的命中统计
+type LRUStats struct {
+	Hits      uint64 // Get 命中次数
+	Misses    uint64 // Get 未命中次数
+	Evictions uint64 // 因超出容量或成本预算被淘汰的键数
+}
+
+type lruEntry[V any] struct {
+	value V
+	cost  int64
+}
+
+type lruEviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
+
+// LRUCache 并发安全的 LRU 缓存，基于 OrderedMap 实现，最近使用的键排在最前
+// 键的数量超过容量或总成本超过预算时，从最久未使用的键开始淘汰
+type LRUCache[K comparable, V any] struct {
+	items    *OrderedMap[K, lruEntry[V]]
+	capacity int
+	budget   int64
+	cost     func(key K, value V) int64
+	total    int64
+	onEvict  func(key K, value V, reason EvictReason)
+	stats    LRUStats
+	mut      *sync.Mutex
+}
+
+// 生成最多容纳 capacity 个键的 LRU 缓存，capacity <= 0 表示不限制键的数量
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	return &LRUCache[K, V]{
+		items:    NewOrderedMap[K, lruEntry[V]](),
+		capacity: capacity,
+		mut:      new(sync.Mutex),
+	}
+}
+
+// 设置成本函数和总成本预算，如按字节数限制缓存大小，budget <= 0 表示不限制
+// 已缓存的键会重新计算成本，单个成本超过预算的键先被淘汰，之后超出预算的部分按最久未使用的顺序淘汰
+func (c *LRUCache[K, V]) SetCost(budget int64, cost func(key K, value V) int64) {
+	c.mut.Lock()
+	c.budget, c.cost, c.total = budget, cost, 0
+	var evicted []lruEviction[K, V]
+	for k, e := range c.items.All() {
+		e.cost = c.costOf(k, e.value)
+		if c.oversized(e.cost) {
+			c.items.Delete(k)
+			c.stats.Evictions++
+			evicted = append(evicted, lruEviction[K, V]{k, e.value, EvictCapacity})
+			continue
+		}
+		c.items.Set(k, e)
+		c.total += e.cost
+	}
+	evicted = c.evict(evicted)
+	c.mut.Unlock()
+	c.notify(evicted)
+}
+
+// 设置键值对移出缓存时的回调，回调在释放锁之后执行，可以在回调中访问缓存
+func (c *LRUCache[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	c.onEvict = fn
+}
+
+// 写入键值对并标记为最近使用
+// 单个键值对的成本超过预算时不写入并返回 false，缓存中已有的键值对（包括同一个键的旧值）保持不变
+func (c *LRUCache[K, V]) Set(key K, value V) bool {
+	c.mut.Lock()
+	e := lruEntry[V]{value: value, cost: c.costOf(key, value)}
+	if c.oversized(e.cost) {
+		c.mut.Unlock()
+		return false
+	}
+	var evicted []lruEviction[K, V]
+	if old, ok := c.items.Get(key); ok {
+		c.total -= old.cost
+		evicted = append(evicted, lruEviction[K, V]{key, old.value, EvictReplaced})
+	}
+	c.items.Set(key, e)
+	c.items.MoveToFront(key)
+	c.total += e.cost
+	evicted = c.evict(evicted)
+	c.mut.Unlock()
+	c.notify(evicted)
+	return true
+}
+
+// 读取键对应的值，命中时标记为最近使用
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	e, ok := c.items.Get(key)
+	if !ok {
+		c.stats.Misses++
+		return e.value, false
+	}
+	c.stats.Hits++
+	c.items.MoveToFront(key)
+	return e.value, true
+}
+
+// 读取键对应的值，不改变使用顺序，也不计入命中统计
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	e, ok := c.items.Get(key)
+	return e.value, ok
+}
+
+// 判断键是否存在，不改变使用顺序
+func (c *LRUCache[K, V]) Contains(key K) bool {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	return c.items.Has(key)
+}
+
+// 删除键
+func (c *LRUCache[K, V]) Remove(key K) bool {
+	c.mut.Lock()
+	e, ok := c.items.Get(key)
+	if ok {
+		c.items.Delete(key)
+		c.total -= e.cost
+	}
+	c.mut.Unlock()
+	if ok {
+		c.notify([]lruEviction[K, V]{{key, e.value, EvictRemoved}})
+	}
+	return ok
+}
+
+// 清空缓存，统计数据保持不变
+func (c *LRUCache[K, V]) Purge() {
+	c.mut.Lock()
+	evicted := make([]lruEviction[K, V], 0, c.items.Len())
+	for k, e, ok := c.items.PopLast(); ok; k, e, ok = c.items.PopLast() {
+		evicted = append(evicted, lruEviction[K, V]{k, e.value, EvictPurged})
+	}
+	c.total = 0
+	c.mut.Unlock()
+	c.notify(evicted)
+}
+
+// 按最近使用到最久未使用的顺序返回所有键
+func (c *LRUCache[K, V]) Keys() []K {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	keys := make([]K, 0, c.items.Len())
+	for k := range c.items.Keys() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// 键的数量
+func (c *LRUCache[K, V]) Len() int {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	return c.items.Len()
+}
+
+// 当前总成本，未设置成本函数时每个键的成本为 1
+func (c *LRUCache[K, V]) Cost() int64 {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	return c.total
+}
+
+// 返回命中、未命中和淘汰次数
+func (c *LRUCache[K, V]) Stats() LRUStats {
+	defer c.mut.Unlock()
+	c.mut.Lock()
+	return c.stats
+}
+
+func (c *LRUCache[K, V]) costOf(key K, value V) int64 {
+	if c.cost == nil {
+		return 1
+	}
+	return c.cost(key, value)
+}
+
+// 判断单个键值对的成本是否超过预算，这样的键值对无论淘汰多少其他键都放不下
+func (c *LRUCache[K, V]) oversized(cost int64) bool {
+	return c.budget > 0 && cost > c.budget
+}
+
+// 从最久未使用的键开始淘汰，直到键的数量和总成本都不超过限制
+func (c *LRUCache[K, V]) evict(evicted []lruEviction[K, V]) []lruEviction[K, V] {
+	for (c.capacity > 0 && c.items.Len() > c.capacity) || (c.budget > 0 && c.total > c.budget) {
+		k, e, ok := c.items.PopLast()
+		if !ok {
+			break
+		}
+		c.total -= e.cost
+		c.stats.Evictions++
+		evicted = append(evicted, lruEviction[K, V]{k, e.value, EvictCapacity})
+	}
+	return evicted
+}
+
+// 在锁外依次执行淘汰回调
+func (c *LRUCache[K, V]) notify(evicted []lruEviction[K, V]) {
+	if len(evicted) == 0 {
+		return
+	}
+	c.mut.Lock()
+	fn := c.onEvict
+	c.mut.Unlock()
+	if fn == nil {
+		return
+	}
+	for _, e := range evicted {
+		fn(e.key, e.value, e.reason)
+	}
+}
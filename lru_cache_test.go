@@ -0,0 +1,118 @@
+package collections
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type evictRecord struct {
+	key    int
+	reason EvictReason
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache[int, string](3)
+	evicted := make([]evictRecord, 0)
+	cache.OnEvict(func(k int, v string, reason EvictReason) {
+		assert.Equal(t, strconv.Itoa(k), v[:len(v)-1])
+		evicted = append(evicted, evictRecord{k, reason})
+	})
+	for i := 0; i < 3; i++ {
+		cache.Set(i, strconv.Itoa(i)+"a")
+	}
+	// 访问 0 后 1 变为最久未使用
+	v, ok := cache.Get(0)
+	assert.True(t, ok)
+	assert.Equal(t, "0a", v)
+	cache.Set(3, "3a")
+	assert.Equal(t, []evictRecord{{1, EvictCapacity}}, evicted)
+	assert.Equal(t, []int{3, 0, 2}, cache.Keys())
+
+	// Peek 不改变顺序
+	v, ok = cache.Peek(2)
+	assert.True(t, ok)
+	assert.Equal(t, "2a", v)
+	cache.Set(0, "0b")
+	cache.Set(4, "4a")
+	assert.Equal(t, []evictRecord{{1, EvictCapacity}, {0, EvictReplaced}, {2, EvictCapacity}}, evicted)
+	assert.Equal(t, []int{4, 0, 3}, cache.Keys())
+
+	_, ok = cache.Get(1)
+	assert.False(t, ok)
+	assert.True(t, cache.Remove(3))
+	assert.False(t, cache.Remove(3))
+	assert.False(t, cache.Contains(3))
+	cache.Purge()
+	assert.Equal(t, 0, cache.Len())
+	assert.Equal(t, int64(0), cache.Cost())
+	assert.Equal(t, []evictRecord{
+		{1, EvictCapacity}, {0, EvictReplaced}, {2, EvictCapacity},
+		{3, EvictRemoved}, {0, EvictPurged}, {4, EvictPurged},
+	}, evicted)
+	assert.Equal(t, LRUStats{Hits: 1, Misses: 1, Evictions: 2}, cache.Stats())
+	assert.Equal(t, "replaced", EvictReplaced.String())
+}
+
+func TestLRUCacheCost(t *testing.T) {
+	cache := NewLRUCache[string, []byte](0)
+	for i := 0; i < 10; i++ {
+		cache.Set(strconv.Itoa(i), make([]byte, 10))
+	}
+	assert.Equal(t, int64(10), cache.Cost())
+
+	// 按字节数限制为 35，只保留最近使用的 3 个键
+	cache.SetCost(35, func(k string, v []byte) int64 { return int64(len(v)) })
+	assert.Equal(t, int64(30), cache.Cost())
+	assert.Equal(t, []string{"9", "8", "7"}, cache.Keys())
+
+	assert.True(t, cache.Set("big", make([]byte, 20)))
+	assert.Equal(t, []string{"big", "9"}, cache.Keys())
+	assert.Equal(t, int64(30), cache.Cost())
+
+	// 成本超过预算的单个值不会写入，也不会淘汰已有的键
+	assert.False(t, cache.Set("huge", make([]byte, 40)))
+	assert.False(t, cache.Set("9", make([]byte, 40)))
+	assert.Equal(t, []string{"big", "9"}, cache.Keys())
+	v, _ := cache.Peek("9")
+	assert.Equal(t, 10, len(v))
+	assert.Equal(t, int64(30), cache.Cost())
+	assert.Equal(t, uint64(7+2), cache.Stats().Evictions)
+
+	// 缩小预算时先淘汰单个成本超过预算的键，再按最久未使用的顺序淘汰
+	cache.SetCost(15, func(k string, v []byte) int64 { return int64(len(v)) })
+	assert.Equal(t, []string{"9"}, cache.Keys())
+	assert.Equal(t, uint64(7+2+1), cache.Stats().Evictions)
+}
+
+func TestLRUCacheConcurrent(t *testing.T) {
+	cache := NewLRUCache[int, int](nums / 2)
+	var mu sync.Mutex
+	evictions := 0
+	cache.OnEvict(func(k, v int, reason EvictReason) {
+		// 回调在锁外执行，可以访问缓存
+		cache.Contains(k)
+		mu.Lock()
+		evictions++
+		mu.Unlock()
+	})
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < nums; i++ {
+				cache.Set(g*nums+i, i)
+				cache.Get(i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, nums/2, cache.Len())
+	assert.Equal(t, 4*nums-nums/2, evictions)
+	stats := cache.Stats()
+	assert.Equal(t, uint64(4*nums), stats.Hits+stats.Misses)
+	assert.Equal(t, uint64(evictions), stats.Evictions)
+}
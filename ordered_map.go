@@ -1,77 +1,203 @@
 package collections
 
-type linkedList struct {
-	next, prev *linkedList
-	key, value interface{}
-}
-
-type OrderedMap struct {
-	head, tail, current *linkedList
-	len                 int
-	items               map[interface{}]*linkedList
-}
-
-func NewOrderedMap() *OrderedMap {
-	headNode := &linkedList{}
-	tailNode := &linkedList{}
-	headNode.next, tailNode.prev = tailNode, headNode
-	return &OrderedMap{
-		head:    headNode,
-		tail:    tailNode,
-		current: headNode,
-		len:     0,
-		items:   make(map[interface{}]*linkedList),
+import "iter"
+
+type linkedList[K comparable, V any] struct {
+	next, prev *linkedList[K, V] // 节点被删除后 prev 置为 nil，next 保留以便正在进行的遍历继续向后移动
+	key        K
+	value      V
+}
+
+// OrderedMap 按插入顺序遍历的 Map，使用 Map 存储键到节点的映射，双向链表记录插入顺序
+type OrderedMap[K comparable, V any] struct {
+	root  linkedList[K, V] // 哨兵节点，root.next 为第一个节点，root.prev 为最后一个节点
+	len   int
+	items map[K]*linkedList[K, V]
+}
+
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	om := &OrderedMap[K, V]{items: make(map[K]*linkedList[K, V])}
+	om.root.next, om.root.prev = &om.root, &om.root
+	return om
+}
+
+// 初始化零值 OrderedMap，如解码时为指针字段分配的 new(OrderedMap)
+func (om *OrderedMap[K, V]) lazyInit() {
+	if om.items == nil {
+		om.items = make(map[K]*linkedList[K, V])
+		om.root.next, om.root.prev = &om.root, &om.root
 	}
 }
 
-func (om *OrderedMap) Set(key, value interface{}) {
-	if _, ok := om.items[key]; ok {
-		om.items[key].value = value
+// 新增键值对，键已存在时只更新值，不改变顺序
+func (om *OrderedMap[K, V]) Set(key K, value V) {
+	if item, ok := om.items[key]; ok {
+		item.value = value
 		return
 	}
-	newNode := &linkedList{prev: om.tail, next: nil, key: key, value: value}
-	om.items[key] = newNode
-	om.tail.next = newNode
-	om.tail = newNode
+	item := &linkedList[K, V]{key: key, value: value}
+	om.insertBefore(item, &om.root)
+	om.items[key] = item
 	om.len++
 }
 
-func (om *OrderedMap) Get(key interface{}) (interface{}, bool) {
+func (om *OrderedMap[K, V]) Get(key K) (V, bool) {
 	if v, ok := om.items[key]; ok {
 		return v.value, ok
 	}
-	return nil, false
+	var zero V
+	return zero, false
+}
+
+// 判断键是否存在
+func (om *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := om.items[key]
+	return ok
+}
+
+// 取值，键不存在时返回 def
+func (om *OrderedMap[K, V]) GetOrDefault(key K, def V) V {
+	if v, ok := om.items[key]; ok {
+		return v.value
+	}
+	return def
 }
 
-func (om *OrderedMap) Delete(key interface{}) bool {
+// 删除键，之后重新插入的键排在最后
+func (om *OrderedMap[K, V]) Delete(key K) bool {
 	item, ok := om.items[key]
 	if !ok {
 		return ok
 	}
-	if item.next == nil {
-		om.tail.prev, om.tail.next = om.tail, nil
-	} else {
-		item.prev.next, item.next.prev = item.next, item.prev
+	om.remove(item)
+	return true
+}
+
+// 将键移动到最后，键不存在时返回 false
+func (om *OrderedMap[K, V]) MoveToEnd(key K) bool {
+	item, ok := om.items[key]
+	if !ok {
+		return false
+	}
+	if om.root.prev != item {
+		om.unlink(item)
+		om.insertBefore(item, &om.root)
 	}
-	delete(om.items, key)
-	item = nil
-	om.len--
 	return true
 }
 
-func (om *OrderedMap) Iter() (interface{}, interface{}, bool) {
-	c := om.current.next
-	if c.next != nil {
-		om.current = om.current.next
-		return c.next.key, c.next.value, true
+// 将键移动到最前，键不存在时返回 false
+func (om *OrderedMap[K, V]) MoveToFront(key K) bool {
+	item, ok := om.items[key]
+	if !ok {
+		return false
+	}
+	if om.root.next != item {
+		om.unlink(item)
+		om.insertBefore(item, om.root.next)
 	}
-	return nil, nil, false
+	return true
+}
+
+// 返回最先插入的键值对
+func (om *OrderedMap[K, V]) Front() (K, V, bool) {
+	return om.entry(om.root.next)
 }
 
-func (om *OrderedMap) BackToHead() {
-	om.current = om.head
+// 返回最后插入的键值对
+func (om *OrderedMap[K, V]) Back() (K, V, bool) {
+	return om.entry(om.root.prev)
 }
 
-func (om *OrderedMap) Len() int {
+// 删除并返回最先插入的键值对，可用于 FIFO 淘汰
+func (om *OrderedMap[K, V]) PopFirst() (K, V, bool) {
+	k, v, ok := om.entry(om.root.next)
+	if ok {
+		om.remove(om.root.next)
+	}
+	return k, v, ok
+}
+
+// 删除并返回最后插入的键值对
+func (om *OrderedMap[K, V]) PopLast() (K, V, bool) {
+	k, v, ok := om.entry(om.root.prev)
+	if ok {
+		om.remove(om.root.prev)
+	}
+	return k, v, ok
+}
+
+// 按插入顺序遍历所有键
+func (om *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range om.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// 按插入顺序遍历所有值
+func (om *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range om.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// 按插入顺序遍历所有键值对，每次调用都是独立的遍历
+// 遍历过程中可以删除键，但移动当前键可能导致遍历提前结束或重复访问
+func (om *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if om.items == nil {
+			return
+		}
+		for item := om.root.next; item != &om.root; item = item.next {
+			// 跳过遍历期间被删除的节点
+			if item.prev == nil {
+				continue
+			}
+			if !yield(item.key, item.value) {
+				return
+			}
+		}
+	}
+}
+
+func (om *OrderedMap[K, V]) Len() int {
 	return om.len
 }
+
+// 返回节点的键值对，哨兵节点表示 Map 为空
+func (om *OrderedMap[K, V]) entry(item *linkedList[K, V]) (K, V, bool) {
+	if item == &om.root {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return item.key, item.value, true
+}
+
+// 将节点插入到 at 之前
+func (om *OrderedMap[K, V]) insertBefore(item, at *linkedList[K, V]) {
+	item.prev, item.next = at.prev, at
+	at.prev.next = item
+	at.prev = item
+}
+
+// 将节点从链表中摘除
+func (om *OrderedMap[K, V]) unlink(item *linkedList[K, V]) {
+	item.prev.next, item.next.prev = item.next, item.prev
+}
+
+// 删除节点，节点的 next 保留以便正在进行的遍历继续向后移动
+func (om *OrderedMap[K, V]) remove(item *linkedList[K, V]) {
+	om.unlink(item)
+	item.prev = nil
+	delete(om.items, item.key)
+	om.len--
+}
@@ -0,0 +1,197 @@
+package collections
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+var (
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// 按插入顺序编码为 JSON 对象
+// 与 encoding/json 对 map 键的要求一致，键的类型需为字符串、整数或实现 encoding.TextMarshaler
+func (om *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	if kt := reflect.TypeFor[K](); kt.Kind() != reflect.String && !kt.Implements(textMarshalerType) && !isIntegerKind(kt.Kind()) {
+		return nil, fmt.Errorf("collections: unsupported OrderedMap key type %v", kt)
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	for k, v := range om.All() {
+		name, err := orderedMapKeyString(k)
+		if err != nil {
+			return nil, err
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("collections: marshal value of key %q: %w", name, err)
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// 按成员出现的顺序解码 JSON 对象，已存在的键只更新值，JSON null 不做任何修改
+// V 为 any 时，嵌套的对象解码为 *OrderedMap[string, any]，保留嵌套对象中的顺序
+func (om *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if kt := reflect.TypeFor[K](); !reflect.PointerTo(kt).Implements(textUnmarshalerType) && kt.Kind() != reflect.String && !isIntegerKind(kt.Kind()) {
+		return fmt.Errorf("collections: unsupported OrderedMap key type %v", kt)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case nil:
+	case json.Delim('{'):
+		if err := om.decodeMembers(dec); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("collections: cannot unmarshal %v into OrderedMap", tok)
+	}
+	// 对象之后不能有其他内容
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("collections: invalid character after top-level OrderedMap value")
+	}
+	return nil
+}
+
+// 解码 '{' 之后的所有成员以及结尾的 '}'
+func (om *OrderedMap[K, V]) decodeMembers(dec *json.Decoder) error {
+	om.lazyInit()
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := tok.(string)
+		var k K
+		if err := orderedMapKeyFromString(&k, name); err != nil {
+			return err
+		}
+		var v V
+		if p, ok := any(&v).(*any); ok {
+			*p, err = decodeOrderedValue(dec)
+		} else {
+			err = dec.Decode(&v)
+		}
+		if err != nil {
+			return fmt.Errorf("collections: unmarshal value of key %q: %w", name, err)
+		}
+		om.Set(k, v)
+	}
+	// 读取结尾的 '}'
+	_, err := dec.Token()
+	return err
+}
+
+// 解码任意 JSON 值，对象解码为 *OrderedMap[string, any]，数组中的对象同样保留顺序
+func decodeOrderedValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		m := NewOrderedMap[string, any]()
+		if err := m.decodeMembers(dec); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case json.Delim('['):
+		arr := make([]any, 0)
+		for dec.More() {
+			v, err := decodeOrderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return arr, nil
+	}
+	return tok, nil
+}
+
+// 将键转为 JSON 对象成员名，规则与 encoding/json 对 map 键的处理一致
+func orderedMapKeyString[K comparable](k K) (string, error) {
+	rv := reflect.ValueOf(&k).Elem()
+	if rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+	if tm, ok := any(k).(encoding.TextMarshaler); ok {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return "", nil
+		}
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("collections: unsupported OrderedMap key type %T", k)
+}
+
+// 由 JSON 对象成员名还原键，规则与 encoding/json 对 map 键的处理一致
+func orderedMapKeyFromString[K comparable](k *K, name string) error {
+	// 与 encoding/json 一致，解码时 encoding.TextUnmarshaler 优先于字符串类型
+	if tu, ok := any(k).(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(name))
+	}
+	rv := reflect.ValueOf(k).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(name)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(name, 10, 64)
+		if err != nil || rv.OverflowInt(n) {
+			return fmt.Errorf("collections: cannot unmarshal key %q into %T", name, *k)
+		}
+		rv.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(name, 10, 64)
+		if err != nil || rv.OverflowUint(n) {
+			return fmt.Errorf("collections: cannot unmarshal key %q into %T", name, *k)
+		}
+		rv.SetUint(n)
+		return nil
+	}
+	return fmt.Errorf("collections: unsupported OrderedMap key type %T", *k)
+}
+
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package collections
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedMapMarshalJSON(t *testing.T) {
+	om := NewOrderedMap[string, any]()
+	om.Set("z", 1)
+	om.Set("a", "x\"y")
+	om.Set("m", []int{1, 2})
+	inner := NewOrderedMap[string, bool]()
+	inner.Set("b", true)
+	inner.Set("a", false)
+	om.Set("inner", inner)
+	om.Set("null", nil)
+
+	data, err := json.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"z":1,"a":"x\"y","m":[1,2],"inner":{"b":true,"a":false},"null":null}`, string(data))
+
+	empty, err := json.Marshal(NewOrderedMap[string, int]())
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, string(empty))
+
+	// 与 encoding/json 一致支持整数键，不支持的键类型即使为空也返回错误
+	ints := NewOrderedMap[int8, int]()
+	ints.Set(-3, 1)
+	ints.Set(2, 2)
+	data, err = json.Marshal(ints)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"-3":1,"2":2}`, string(data))
+	decoded := NewOrderedMap[int8, int]()
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, 1, decoded.GetOrDefault(-3, 0))
+	assert.Error(t, json.Unmarshal([]byte(`{"300":1}`), decoded))
+	assert.Error(t, json.Unmarshal([]byte(`{"x":1}`), NewOrderedMap[uint, int]()))
+
+	_, err = json.Marshal(NewOrderedMap[float64, int]())
+	assert.Error(t, err)
+	assert.Error(t, json.Unmarshal([]byte(`{}`), NewOrderedMap[bool, int]()))
+}
+
+func TestOrderedMapUnmarshalJSON(t *testing.T) {
+	data := `{"z":1,"a":{"y":[{"q":1,"p":2}],"x":null},"m":"s","a2":true}`
+	om := NewOrderedMap[string, any]()
+	assert.NoError(t, json.Unmarshal([]byte(data), om))
+	assert.Equal(t, []string{"z", "a", "m", "a2"}, collectKeys(om))
+
+	// 嵌套的对象同样保留顺序
+	inner, ok := om.GetOrDefault("a", nil).(*OrderedMap[string, any])
+	assert.True(t, ok)
+	assert.Equal(t, []string{"y", "x"}, collectKeys(inner))
+	arr := inner.GetOrDefault("y", nil).([]any)
+	assert.Equal(t, []string{"q", "p"}, collectKeys(arr[0].(*OrderedMap[string, any])))
+
+	out, err := json.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, data, string(out))
+
+	typed := NewOrderedMap[string, int]()
+	typed.Set("b", 0)
+	assert.NoError(t, json.Unmarshal([]byte(`{"c":3,"b":2}`), typed))
+	assert.Equal(t, []string{"b", "c"}, collectKeys(typed))
+	assert.Equal(t, 2, typed.GetOrDefault("b", 0))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"c":"x"}`), typed))
+	assert.Error(t, json.Unmarshal([]byte(`[1]`), typed))
+	assert.NoError(t, json.Unmarshal([]byte(`null`), typed))
+	assert.Equal(t, 2, typed.Len())
+
+	// 直接调用时同样检查对象之后是否还有其他内容
+	assert.Error(t, typed.UnmarshalJSON([]byte(`{"d":4} {"e":5}`)))
+	assert.Error(t, typed.UnmarshalJSON([]byte(`{"d":4}}`)))
+	assert.NoError(t, typed.UnmarshalJSON([]byte(" {\"d\":4}\n")))
+}
+
+func TestOrderedMapJSONField(t *testing.T) {
+	type response struct {
+		Data *OrderedMap[string, int] `json:"data"`
+	}
+	var resp response
+	assert.NoError(t, json.Unmarshal([]byte(`{"data":{"b":2,"a":1}}`), &resp))
+	assert.Equal(t, []string{"b", "a"}, collectKeys(resp.Data))
+	out, err := json.Marshal(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"data":{"b":2,"a":1}}`, string(out))
+}
+
+type textKey int
+
+func (k textKey) MarshalText() ([]byte, error) { return []byte("k" + strconv.Itoa(int(k))), nil }
+
+func (k *textKey) UnmarshalText(text []byte) error {
+	n, err := strconv.Atoi(string(text[1:]))
+	*k = textKey(n)
+	return err
+}
+
+// 字符串类型的键实现了 encoding.TextUnmarshaler
+type upperKey string
+
+func (k *upperKey) UnmarshalText(text []byte) error {
+	*k = upperKey(strings.ToUpper(string(text)))
+	return nil
+}
+
+func TestOrderedMapTextKeys(t *testing.T) {
+	om := NewOrderedMap[textKey, string]()
+	om.Set(2, "b")
+	om.Set(1, "a")
+	data, err := json.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"k2":"b","k1":"a"}`, string(data))
+
+	decoded := NewOrderedMap[textKey, string]()
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, "a", decoded.GetOrDefault(1, ""))
+	k, _, _ := decoded.Front()
+	assert.Equal(t, textKey(2), k)
+
+	// 与 encoding/json 一致，解码时 UnmarshalText 优先于字符串类型
+	var std map[upperKey]int
+	assert.NoError(t, json.Unmarshal([]byte(`{"a":1}`), &std))
+	upper := NewOrderedMap[upperKey, int]()
+	assert.NoError(t, json.Unmarshal([]byte(`{"a":1}`), upper))
+	for k := range std {
+		assert.True(t, upper.Has(k))
+	}
+	assert.Equal(t, 1, upper.GetOrDefault("A", 0))
+}
+
+func collectKeys[V any](om *OrderedMap[string, V]) []string {
+	keys := make([]string, 0, om.Len())
+	for k := range om.Keys() {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -1,15 +1,17 @@
 package collections
 
 import (
+	"iter"
 	"testing"
 
 	"github.com/cevaris/ordered_map"
+	"github.com/stretchr/testify/assert"
 )
 
 const maxNum = 100
 
 func BenchmarkCollectionsSet(b *testing.B) {
-	om := NewOrderedMap()
+	om := NewOrderedMap[int, int]()
 	for i := 0; i < b.N; i++ {
 		om.Set(i, i)
 	}
@@ -23,7 +25,7 @@ func BenchmarkCevarisSet(b *testing.B) {
 }
 
 func BenchmarkCollectionsGet(b *testing.B) {
-	om := NewOrderedMap()
+	om := NewOrderedMap[int, int]()
 	for i := 0; i < b.N; i++ {
 		om.Set(i, i)
 	}
@@ -43,11 +45,11 @@ func BenchmarkCevarisGet(b *testing.B) {
 }
 
 func BenchmarkCollectionsIter(b *testing.B) {
-	om := NewOrderedMap()
+	om := NewOrderedMap[int, int]()
 	for i := 0; i < b.N; i++ {
 		om.Set(i, i)
 	}
-	for k, v, ok := om.Iter(); ok; k, v, ok = om.Iter() {
+	for k, v := range om.All() {
 		_, _ = k, v
 	}
 }
@@ -64,7 +66,7 @@ func BenchmarkCevarisIter(b *testing.B) {
 }
 
 func TestSet(t *testing.T) {
-	om := NewOrderedMap()
+	om := NewOrderedMap[int, int]()
 	for i := 0; i < maxNum; i++ {
 		om.Set(i, i+1)
 	}
@@ -74,20 +76,20 @@ func TestSet(t *testing.T) {
 }
 
 func TestGet(t *testing.T) {
-	om := NewOrderedMap()
+	om := NewOrderedMap[int, int]()
 	for i := 0; i < maxNum; i++ {
 		om.Set(i, i+1)
 	}
 	for i := 0; i < maxNum; i++ {
 		v, ok := om.Get(i)
-		if !ok || v.(int) != i+1 {
+		if !ok || v != i+1 {
 			t.Error()
 		}
 	}
 }
 
 func TestGUpdate(t *testing.T) {
-	om := NewOrderedMap()
+	om := NewOrderedMap[int, int]()
 	for i := 0; i < maxNum; i++ {
 		om.Set(i, i+1)
 	}
@@ -96,31 +98,173 @@ func TestGUpdate(t *testing.T) {
 	}
 	for i := 0; i < maxNum; i++ {
 		v, ok := om.Get(i)
-		if !ok || v.(int) != i-1 {
+		if !ok || v != i-1 {
 			t.Error()
 		}
 	}
 }
 
 func TestIter(t *testing.T) {
-	om := NewOrderedMap()
+	om := NewOrderedMap[int, int]()
 	for i := 0; i < maxNum; i++ {
 		om.Set(i, i+1)
 	}
 	index := 0
-	for k, v, ok := om.Iter(); ok; k, v, ok = om.Iter() {
-		if k.(int) != index || v.(int) != index+1 || !ok {
+	for k, v := range om.All() {
+		if k != index || v != index+1 {
 			t.Error()
 		}
 		index++
 	}
+	if index != maxNum {
+		t.Error()
+	}
 
+	// 每次遍历相互独立，可以交替进行
+	next, stop := iter.Pull(om.Keys())
+	defer stop()
 	index = 0
-	om.BackToHead()
-	for k, v, ok := om.Iter(); ok; k, v, ok = om.Iter() {
-		if k.(int) != index || v.(int) != index+1 || !ok {
+	for v := range om.Values() {
+		k, ok := next()
+		if !ok || k != index || v != index+1 {
 			t.Error()
 		}
 		index++
 	}
 }
+
+func TestDelete(t *testing.T) {
+	om := NewOrderedMap[int, int]()
+	for i := 0; i < maxNum; i++ {
+		om.Set(i, i)
+	}
+	// 删除第一个、中间和最后一个键
+	for _, k := range []int{0, maxNum / 2, maxNum - 1} {
+		if !om.Delete(k) || om.Delete(k) {
+			t.Error()
+		}
+	}
+	om.Set(0, 0)
+	om.Set(maxNum, maxNum)
+	om.Set(1, -1)
+	if om.Len() != maxNum-1 {
+		t.Error()
+	}
+
+	want := make([]int, 0)
+	for i := 1; i < maxNum-1; i++ {
+		if i != maxNum/2 {
+			want = append(want, i)
+		}
+	}
+	want = append(want, 0, maxNum)
+	got := make([]int, 0)
+	for k := range om.Keys() {
+		got = append(got, k)
+	}
+	assert.Equal(t, want, got)
+	v, _ := om.Get(1)
+	assert.Equal(t, -1, v)
+
+	for k := range om.Keys() {
+		om.Delete(k)
+	}
+	assert.Equal(t, 0, om.Len())
+	for range om.All() {
+		t.Error()
+	}
+	om.Set(1, 1)
+	for k, v := range om.All() {
+		assert.Equal(t, 1, k)
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestDeleteWhileIterating(t *testing.T) {
+	om := NewOrderedMap[int, int]()
+	for i := 0; i < maxNum; i++ {
+		om.Set(i, i)
+	}
+	got := make([]int, 0)
+	for k := range om.Keys() {
+		// 删除当前键以及后面一个键，被删除的键不会再出现
+		om.Delete(k)
+		om.Delete(k + 1)
+		got = append(got, k)
+	}
+	assert.Equal(t, maxNum/2, len(got))
+	for i, k := range got {
+		assert.Equal(t, 2*i, k)
+	}
+	assert.Equal(t, 0, om.Len())
+}
+
+func TestHasGetOrDefault(t *testing.T) {
+	om := NewOrderedMap[string, []int]()
+	om.Set("a", []int{1})
+	om.Set("b", nil)
+	assert.True(t, om.Has("a"))
+	assert.True(t, om.Has("b"))
+	assert.False(t, om.Has("c"))
+	assert.Equal(t, []int{1}, om.GetOrDefault("a", []int{0}))
+	assert.Nil(t, om.GetOrDefault("b", []int{0}))
+	assert.Equal(t, []int{0}, om.GetOrDefault("c", []int{0}))
+	v, ok := om.Get("c")
+	assert.False(t, ok)
+	assert.Nil(t, v)
+}
+
+func orderedKeys(om *OrderedMap[int, int]) []int {
+	keys := make([]int, 0, om.Len())
+	for k := range om.Keys() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestMoveAndPop(t *testing.T) {
+	om := NewOrderedMap[int, int]()
+	_, _, ok := om.Front()
+	assert.False(t, ok)
+	_, _, ok = om.PopLast()
+	assert.False(t, ok)
+	assert.False(t, om.MoveToEnd(0))
+	assert.False(t, om.MoveToFront(0))
+
+	for i := 0; i < 5; i++ {
+		om.Set(i, i*10)
+	}
+	assert.True(t, om.MoveToEnd(1))
+	assert.True(t, om.MoveToEnd(1))
+	assert.True(t, om.MoveToFront(3))
+	assert.True(t, om.MoveToFront(3))
+	assert.Equal(t, []int{3, 0, 2, 4, 1}, orderedKeys(om))
+
+	k, v, ok := om.Front()
+	assert.True(t, ok)
+	assert.Equal(t, 3, k)
+	assert.Equal(t, 30, v)
+	k, v, ok = om.Back()
+	assert.True(t, ok)
+	assert.Equal(t, 1, k)
+	assert.Equal(t, 10, v)
+
+	k, _, _ = om.PopFirst()
+	assert.Equal(t, 3, k)
+	k, _, _ = om.PopLast()
+	assert.Equal(t, 1, k)
+	assert.False(t, om.Has(1))
+	assert.False(t, om.Has(3))
+	assert.Equal(t, 3, om.Len())
+	assert.Equal(t, []int{0, 2, 4}, orderedKeys(om))
+
+	// 弹出的键重新插入后排在最后
+	om.Set(3, 3)
+	assert.Equal(t, []int{0, 2, 4, 3}, orderedKeys(om))
+	for om.Len() > 0 {
+		om.PopFirst()
+	}
+	_, _, ok = om.Back()
+	assert.False(t, ok)
+	assert.Equal(t, []int{}, orderedKeys(om))
+}
@@ -0,0 +1,84 @@
+package collections
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 按插入顺序编码为 YAML 映射，实现 yaml.Marshaler 接口
+func (om *OrderedMap[K, V]) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for k, v := range om.All() {
+		key, value := new(yaml.Node), new(yaml.Node)
+		if err := key.Encode(k); err != nil {
+			return nil, err
+		}
+		if err := value.Encode(v); err != nil {
+			return nil, fmt.Errorf("collections: marshal value of key %v: %w", k, err)
+		}
+		node.Content = append(node.Content, key, value)
+	}
+	return node, nil
+}
+
+// 按成员出现的顺序解码 YAML 映射，实现 yaml.Unmarshaler 接口，已存在的键只更新值，null 不做任何修改
+// V 为 any 时，嵌套的映射解码为 *OrderedMap[string, any]，保留嵌套映射中的顺序
+func (om *OrderedMap[K, V]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("collections: cannot unmarshal YAML %s into OrderedMap", node.ShortTag())
+	}
+	om.lazyInit()
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var k K
+		if err := node.Content[i].Decode(&k); err != nil {
+			return err
+		}
+		var v V
+		var err error
+		if p, ok := any(&v).(*any); ok {
+			*p, err = decodeOrderedYAML(node.Content[i+1])
+		} else {
+			err = node.Content[i+1].Decode(&v)
+		}
+		if err != nil {
+			return fmt.Errorf("collections: unmarshal value of key %v: %w", k, err)
+		}
+		om.Set(k, v)
+	}
+	return nil
+}
+
+// 解码任意 YAML 节点，映射解码为 *OrderedMap[string, any]，序列中的映射同样保留顺序
+func decodeOrderedYAML(node *yaml.Node) (any, error) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		m := NewOrderedMap[string, any]()
+		if err := m.UnmarshalYAML(node); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		arr := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			v, err := decodeOrderedYAML(item)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		return arr, nil
+	}
+	var v any
+	err := node.Decode(&v)
+	return v, err
+}
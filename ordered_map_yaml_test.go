@@ -0,0 +1,81 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestOrderedMapMarshalYAML(t *testing.T) {
+	om := NewOrderedMap[string, any]()
+	om.Set("z", 1)
+	om.Set("a", "x")
+	inner := NewOrderedMap[string, bool]()
+	inner.Set("b", true)
+	inner.Set("a", false)
+	om.Set("inner", inner)
+	om.Set("list", []int{1, 2})
+
+	data, err := yaml.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, "z: 1\na: x\ninner:\n    b: true\n    a: false\nlist:\n    - 1\n    - 2\n", string(data))
+
+	ints := NewOrderedMap[int, string]()
+	ints.Set(2, "b")
+	ints.Set(1, "a")
+	data, err = yaml.Marshal(ints)
+	assert.NoError(t, err)
+	assert.Equal(t, "2: b\n1: a\n", string(data))
+
+	empty, err := yaml.Marshal(NewOrderedMap[string, int]())
+	assert.NoError(t, err)
+	assert.Equal(t, "{}\n", string(empty))
+}
+
+func TestOrderedMapUnmarshalYAML(t *testing.T) {
+	data := "z: 1\na:\n    w:\n        - q: 1\n          p: 2\n    v: null\nm: s\n"
+	om := NewOrderedMap[string, any]()
+	assert.NoError(t, yaml.Unmarshal([]byte(data), om))
+	assert.Equal(t, []string{"z", "a", "m"}, collectKeys(om))
+
+	// 嵌套的映射同样保留顺序
+	inner, ok := om.GetOrDefault("a", nil).(*OrderedMap[string, any])
+	assert.True(t, ok)
+	assert.Equal(t, []string{"w", "v"}, collectKeys(inner))
+	arr := inner.GetOrDefault("w", nil).([]any)
+	assert.Equal(t, []string{"q", "p"}, collectKeys(arr[0].(*OrderedMap[string, any])))
+
+	out, err := yaml.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, data, string(out))
+
+	typed := NewOrderedMap[int, int]()
+	typed.Set(2, 0)
+	assert.NoError(t, yaml.Unmarshal([]byte("3: 3\n2: 2\n"), typed))
+	assert.Equal(t, 2, typed.GetOrDefault(2, 0))
+	k, _, _ := typed.Front()
+	assert.Equal(t, 2, k)
+
+	assert.Error(t, yaml.Unmarshal([]byte("c: x\n"), typed))
+	assert.Error(t, yaml.Unmarshal([]byte("- 1\n"), typed))
+	assert.NoError(t, yaml.Unmarshal([]byte("null\n"), typed))
+	assert.Equal(t, 2, typed.Len())
+
+	// 别名指向的映射同样保留顺序
+	anchors := NewOrderedMap[string, any]()
+	assert.NoError(t, yaml.Unmarshal([]byte("base: &b\n    y: 1\n    x: 2\ncopy: *b\n"), anchors))
+	assert.Equal(t, []string{"y", "x"}, collectKeys(anchors.GetOrDefault("copy", nil).(*OrderedMap[string, any])))
+}
+
+func TestOrderedMapYAMLField(t *testing.T) {
+	type config struct {
+		Data *OrderedMap[string, int] `yaml:"data"`
+	}
+	var conf config
+	assert.NoError(t, yaml.Unmarshal([]byte("data:\n    b: 2\n    a: 1\n"), &conf))
+	assert.Equal(t, []string{"b", "a"}, collectKeys(conf.Data))
+	out, err := yaml.Marshal(conf)
+	assert.NoError(t, err)
+	assert.Equal(t, "data:\n    b: 2\n    a: 1\n", string(out))
+}
@@ -0,0 +1,34 @@
+package collections
+
+import "iter"
+
+// OrderedSet 按键排序的集合，AVLTree、RBTree、BTree、SkipList、SplayTree 和 Treap 都实现了该接口，可以相互替换
+type OrderedSet[K, V any] interface {
+	Insert(k K)
+	Search(k K) bool
+	Delete(k K) bool
+	GetMinValue() (K, bool)
+	GetMaxValue() (K, bool)
+	AllValues() []K
+	Len() int
+	Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool)
+	Ascend() iter.Seq2[K, V]
+	Descend() iter.Seq2[K, V]
+}
+
+// SortedMap 在 OrderedSet 的基础上支持按键存取值
+type SortedMap[K, V any] interface {
+	OrderedSet[K, V]
+	Put(k K, v V)
+	Get(k K) (V, bool)
+	Contains(k K) bool
+}
+
+var (
+	_ SortedMap[int, int] = (*AVLTree[int, int])(nil)
+	_ SortedMap[int, int] = (*RBTree[int, int])(nil)
+	_ SortedMap[int, int] = (*BTree[int, int])(nil)
+	_ SortedMap[int, int] = (*SkipList[int, int])(nil)
+	_ SortedMap[int, int] = (*SplayTree[int, int])(nil)
+	_ SortedMap[int, int] = (*Treap[int, int])(nil)
+)
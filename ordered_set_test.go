@@ -0,0 +1,145 @@
+package collections
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var orderedSets = []struct {
+	name string
+	new  func() OrderedSet[int, struct{}]
+}{
+	{"AVLTree", func() OrderedSet[int, struct{}] { return NewAVLTree[int, struct{}]() }},
+	{"RBTree", func() OrderedSet[int, struct{}] { return NewRBTree[int, struct{}]() }},
+	{"BTree", func() OrderedSet[int, struct{}] { return NewBTree[int, struct{}](32) }},
+	{"SkipList", func() OrderedSet[int, struct{}] { return NewSkipList[int, struct{}]() }},
+	{"SplayTree", func() OrderedSet[int, struct{}] { return NewSplayTree[int, struct{}]() }},
+	{"Treap", func() OrderedSet[int, struct{}] { return NewTreap[int, struct{}]() }},
+}
+
+func TestOrderedSet(t *testing.T) {
+	for _, impl := range orderedSets {
+		t.Run(impl.name, func(t *testing.T) {
+			set := impl.new()
+			oracle := make(map[int]bool)
+			for i := 0; i < nums; i++ {
+				k := rand.Intn(nums)
+				if i%4 == 3 {
+					assert.Equal(t, oracle[k], set.Delete(k))
+					delete(oracle, k)
+				} else {
+					set.Insert(k)
+					oracle[k] = true
+				}
+			}
+			want := make([]int, 0, len(oracle))
+			for k := range oracle {
+				want = append(want, k)
+			}
+			sort.Ints(want)
+			assert.Equal(t, want, set.AllValues())
+			assert.Equal(t, len(want), set.Len())
+
+			minKey, _ := set.GetMinValue()
+			maxKey, _ := set.GetMaxValue()
+			assert.Equal(t, want[0], minKey)
+			assert.Equal(t, want[len(want)-1], maxKey)
+
+			got := make([]int, 0)
+			set.Range(want[1], want[5], IncludeBoth, func(k int, _ struct{}) bool {
+				got = append(got, k)
+				return true
+			})
+			assert.Equal(t, want[1:6], got)
+
+			got = got[:0]
+			for k := range set.Descend() {
+				got = append(got, k)
+			}
+			assert.Equal(t, len(want), len(got))
+			assert.Equal(t, want[len(want)-1], got[0])
+		})
+	}
+}
+
+// 键的取值范围，集合始终保持其中一半的键
+const benchKeys = 1 << 16
+
+// 每批操作的数量，每批结束后把集合恢复到 benchKeys/2 个键，避免集合在插入或删除为主的场景下被填满或清空
+const benchBatch = 1 << 10
+
+// 按 insert% 插入、del% 删除、其余查找的比例执行随机操作，skewed 为 true 时查找的键服从 Zipf 分布
+// 插入只插入不存在的键，删除只删除存在的键，保证每次操作都符合场景的名称
+func benchmarkOrderedSet(b *testing.B, set OrderedSet[int, struct{}], insert, del int, skewed bool) {
+	r := rand.New(rand.NewSource(1))
+	// keys[:n] 在集合中，keys[n:] 不在集合中
+	keys := r.Perm(benchKeys)
+	n := benchKeys / 2
+	for _, k := range keys[:n] {
+		set.Insert(k)
+	}
+	zipf := rand.NewZipf(r, 1.1, 1, benchKeys-1)
+	ops := make([]int, benchBatch)
+	picks := make([]int, benchBatch)
+	b.ResetTimer()
+	for i := 0; i < b.N; i += benchBatch {
+		b.StopTimer()
+		for ; n > benchKeys/2; n-- {
+			j := r.Intn(n)
+			set.Delete(keys[j])
+			keys[j], keys[n-1] = keys[n-1], keys[j]
+		}
+		for ; n < benchKeys/2; n++ {
+			j := n + r.Intn(benchKeys-n)
+			set.Insert(keys[j])
+			keys[j], keys[n] = keys[n], keys[j]
+		}
+		for j := range ops {
+			ops[j] = r.Intn(100)
+			picks[j] = r.Intn(benchKeys)
+			if skewed && ops[j] >= insert+del {
+				picks[j] = int(zipf.Uint64())
+			}
+		}
+		b.StartTimer()
+		for j := 0; j < benchBatch && i+j < b.N; j++ {
+			switch op := ops[j]; {
+			case op < insert:
+				x := n + picks[j]%(benchKeys-n)
+				set.Insert(keys[x])
+				keys[x], keys[n] = keys[n], keys[x]
+				n++
+			case op < insert+del:
+				x := picks[j] % n
+				set.Delete(keys[x])
+				keys[x], keys[n-1] = keys[n-1], keys[x]
+				n--
+			default:
+				set.Search(picks[j])
+			}
+		}
+	}
+}
+
+func BenchmarkOrderedSet(b *testing.B) {
+	mixes := []struct {
+		name        string
+		insert, del int
+		skewed      bool
+	}{
+		{"InsertHeavy", 80, 10, false},
+		{"DeleteHeavy", 10, 80, false},
+		{"LookupHeavy", 10, 10, false},
+		{"LookupSkewed", 10, 10, true},
+	}
+	for _, mix := range mixes {
+		for _, impl := range orderedSets {
+			b.Run(mix.name+"/"+impl.name, func(b *testing.B) {
+				benchmarkOrderedSet(b, impl.new(), mix.insert, mix.del, mix.skewed)
+			})
+		}
+	}
+}
@@ -2,6 +2,7 @@ package collections
 
 import (
 	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,7 +16,7 @@ func TestPriorityQueue(t *testing.T) {
 
 	for i := 0; i < nums; i++ {
 		r := rand.Int()
-		q.Put(&PqNode{Value: string(r), Priority: rand.Int()})
+		q.Put(&PqNode{Value: strconv.Itoa(r), Priority: rand.Int()})
 	}
 
 	for i := 0; i < nums/2; i++ {
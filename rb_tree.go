@@ -0,0 +1,457 @@
+package collections
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+type rbNode[K, V any] struct {
+	red    bool
+	key    K
+	value  V
+	left   *rbNode[K, V]
+	right  *rbNode[K, V]
+	parent *rbNode[K, V]
+}
+
+// RBTree 经典红黑树，插入和删除后自底向上恢复颜色约束
+// 与 AVLTree 相比平衡条件更宽松，树高更高，但插入最多旋转 2 次、删除最多旋转 3 次，其余调整只需改变颜色
+type RBTree[K, V any] struct {
+	tree *rbNode[K, V]
+	n    int
+	cmp  func(a, b K) int
+}
+
+// 生成红黑树，键类型需满足 cmp.Ordered
+func NewRBTree[K cmp.Ordered, V any]() *RBTree[K, V] {
+	return NewRBTreeFunc[K, V](cmp.Compare[K])
+}
+
+// 使用自定义比较函数生成红黑树
+func NewRBTreeFunc[K, V any](cmp func(a, b K) int) *RBTree[K, V] {
+	return &RBTree[K, V]{cmp: cmp}
+}
+
+// 插入或更新键值对
+func (r *RBTree[K, V]) Put(k K, v V) {
+	var parent *rbNode[K, V]
+	cmp := 0
+	for t := r.tree; t != nil; {
+		parent = t
+		cmp = r.cmp(k, t.key)
+		if cmp > 0 {
+			t = t.right
+		} else if cmp < 0 {
+			t = t.left
+		} else {
+			t.value = v
+			return
+		}
+	}
+	// 新节点总是红色，不改变黑高，只可能与父节点形成连续的红节点
+	node := &rbNode[K, V]{red: true, key: k, value: v, parent: parent}
+	if parent == nil {
+		r.tree = node
+	} else if cmp < 0 {
+		parent.left = node
+	} else {
+		parent.right = node
+	}
+	r.n++
+	r.insertFixUp(node)
+}
+
+// 获取键对应的值
+func (r *RBTree[K, V]) Get(k K) (V, bool) {
+	if t := r.search(k); t != nil {
+		return t.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// 判断键是否存在
+func (r *RBTree[K, V]) Contains(k K) bool {
+	return r.search(k) != nil
+}
+
+// 插入节点，值为 V 的零值
+func (r *RBTree[K, V]) Insert(k K) {
+	var zero V
+	r.Put(k, zero)
+}
+
+// 搜索节点
+func (r *RBTree[K, V]) Search(k K) bool {
+	return r.Contains(k)
+}
+
+// 删除节点
+func (r *RBTree[K, V]) Delete(k K) bool {
+	z := r.search(k)
+	if z == nil {
+		return false
+	}
+	// y 为实际从树中移走的节点，x 为移到 y 原来位置的节点，可能为 nil，因此单独记录其父节点
+	y, removedRed := z, z.red
+	var x, parent *rbNode[K, V]
+	if z.left == nil {
+		x, parent = z.right, z.parent
+		r.transplant(z, z.right)
+	} else if z.right == nil {
+		x, parent = z.left, z.parent
+		r.transplant(z, z.left)
+	} else {
+		// 使用右子树中的最小节点取代删除节点，并继承删除节点的颜色
+		y = z.right.minNode()
+		removedRed = y.red
+		x = y.right
+		if y.parent == z {
+			parent = y
+		} else {
+			parent = y.parent
+			r.transplant(y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		r.transplant(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.red = z.red
+	}
+	r.n--
+	// 移走红色节点不影响任何约束
+	if !removedRed {
+		r.deleteFixUp(x, parent)
+	}
+	return true
+}
+
+// 获取所有节点中的最大键，空树返回 false
+func (r *RBTree[K, V]) GetMaxValue() (K, bool) {
+	t := r.tree
+	if t == nil {
+		var zero K
+		return zero, false
+	}
+	for t.right != nil {
+		t = t.right
+	}
+	return t.key, true
+}
+
+// 获取所有节点中的最小键，空树返回 false
+func (r *RBTree[K, V]) GetMinValue() (K, bool) {
+	t := r.tree.minNode()
+	if t == nil {
+		var zero K
+		return zero, false
+	}
+	return t.key, true
+}
+
+// 返回排序后所有键
+func (r *RBTree[K, V]) AllValues() []K {
+	keys := make([]K, 0, r.n)
+	for k := range r.Ascend() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// 按键的顺序返回所有值
+func (r *RBTree[K, V]) Values() []V {
+	values := make([]V, 0, r.n)
+	for _, v := range r.Ascend() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// 节点数量
+func (r *RBTree[K, V]) Len() int {
+	return r.n
+}
+
+// 按键升序遍历 [lo, hi] 区间内的键值对，bound 决定是否包含边界，fn 返回 false 时停止遍历
+func (r *RBTree[K, V]) Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool) {
+	var stack []*rbNode[K, V]
+	// 定位到第一个满足下界的节点，沿途记录需要回溯的节点
+	for t := r.tree; t != nil; {
+		cmp := r.cmp(t.key, lo)
+		if cmp > 0 || (cmp == 0 && bound&IncludeLo != 0) {
+			stack = append(stack, t)
+			t = t.left
+		} else {
+			t = t.right
+		}
+	}
+	for len(stack) > 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		cmp := r.cmp(t.key, hi)
+		if cmp > 0 || (cmp == 0 && bound&IncludeHi == 0) {
+			return
+		}
+		if !fn(t.key, t.value) {
+			return
+		}
+		for t = t.right; t != nil; t = t.left {
+			stack = append(stack, t)
+		}
+	}
+}
+
+// 返回按键升序遍历的迭代器
+func (r *RBTree[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*rbNode[K, V]
+		for t := r.tree; t != nil; t = t.left {
+			stack = append(stack, t)
+		}
+		for len(stack) > 0 {
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(t.key, t.value) {
+				return
+			}
+			for t = t.right; t != nil; t = t.left {
+				stack = append(stack, t)
+			}
+		}
+	}
+}
+
+// 返回按键降序遍历的迭代器
+func (r *RBTree[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*rbNode[K, V]
+		for t := r.tree; t != nil; t = t.right {
+			stack = append(stack, t)
+		}
+		for len(stack) > 0 {
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(t.key, t.value) {
+				return
+			}
+			for t = t.left; t != nil; t = t.right {
+				stack = append(stack, t)
+			}
+		}
+	}
+}
+
+// 检查整棵树是否满足 BST 有序性、无连续红节点以及黑高一致的约束
+func (r *RBTree[K, V]) Validate() error {
+	if r.tree.isRed() {
+		return errors.New("collections: root is red")
+	}
+	if r.tree != nil && r.tree.parent != nil {
+		return errors.New("collections: root has a parent")
+	}
+	_, n, err := r.validate(r.tree, nil, nil)
+	if err != nil {
+		return err
+	}
+	if n != r.n {
+		return fmt.Errorf("collections: tree stores size %d, want %d", r.n, n)
+	}
+	return nil
+}
+
+// 校验以 t 为根的子树，返回子树黑高和节点数
+func (r *RBTree[K, V]) validate(t, lo, hi *rbNode[K, V]) (int, int, error) {
+	if t == nil {
+		return 0, 0, nil
+	}
+	if lo != nil && r.cmp(t.key, lo.key) <= 0 {
+		return 0, 0, fmt.Errorf("collections: key %v is not greater than ancestor %v", t.key, lo.key)
+	}
+	if hi != nil && r.cmp(t.key, hi.key) >= 0 {
+		return 0, 0, fmt.Errorf("collections: key %v is not less than ancestor %v", t.key, hi.key)
+	}
+	for _, child := range []*rbNode[K, V]{t.left, t.right} {
+		if child == nil {
+			continue
+		}
+		if child.parent != t {
+			return 0, 0, fmt.Errorf("collections: node %v has a wrong parent", child.key)
+		}
+		if t.red && child.red {
+			return 0, 0, fmt.Errorf("collections: node %v and its child %v are both red", t.key, child.key)
+		}
+	}
+	lb, ln, err := r.validate(t.left, lo, t)
+	if err != nil {
+		return 0, 0, err
+	}
+	rb, rn, err := r.validate(t.right, t, hi)
+	if err != nil {
+		return 0, 0, err
+	}
+	if lb != rb {
+		return 0, 0, fmt.Errorf("collections: node %v has black heights %d and %d", t.key, lb, rb)
+	}
+	if !t.red {
+		lb++
+	}
+	return lb, ln + rn + 1, nil
+}
+
+func (r *RBTree[K, V]) search(k K) *rbNode[K, V] {
+	t := r.tree
+	for t != nil {
+		cmp := r.cmp(k, t.key)
+		if cmp > 0 {
+			t = t.right
+		} else if cmp < 0 {
+			t = t.left
+		} else {
+			return t
+		}
+	}
+	return nil
+}
+
+// 插入红色节点 z 后恢复约束
+// 叔节点为红色时只需改变颜色并将问题上移两层，否则最多旋转 2 次后结束
+func (r *RBTree[K, V]) insertFixUp(z *rbNode[K, V]) {
+	// 父节点为红色时一定不是根节点，因此祖父节点存在
+	for z.parent.isRed() {
+		p, g := z.parent, z.parent.parent
+		if p == g.left {
+			if u := g.right; u.isRed() {
+				p.red, u.red, g.red = false, false, true
+				z = g
+				continue
+			}
+			if z == p.right {
+				r.rotateLeft(p)
+				p = z
+			}
+			p.red, g.red = false, true
+			r.rotateRight(g)
+			break
+		} else {
+			if u := g.left; u.isRed() {
+				p.red, u.red, g.red = false, false, true
+				z = g
+				continue
+			}
+			if z == p.left {
+				r.rotateRight(p)
+				p = z
+			}
+			p.red, g.red = false, true
+			r.rotateLeft(g)
+			break
+		}
+	}
+	r.tree.red = false
+}
+
+// 移走黑色节点后，经过 x 的路径少了一个黑节点，恢复约束
+// 兄弟节点及其子节点都为黑色时只需改变颜色并将问题上移一层，否则最多旋转 3 次后结束
+func (r *RBTree[K, V]) deleteFixUp(x, parent *rbNode[K, V]) {
+	for x != r.tree && !x.isRed() {
+		// x 所在一侧的黑高比另一侧少 1，因此兄弟节点一定存在
+		if x == parent.left {
+			w := parent.right
+			if w.isRed() {
+				w.red, parent.red = false, true
+				r.rotateLeft(parent)
+				w = parent.right
+			}
+			if !w.left.isRed() && !w.right.isRed() {
+				w.red = true
+				x, parent = parent, parent.parent
+				continue
+			}
+			if !w.right.isRed() {
+				w.left.red, w.red = false, true
+				r.rotateRight(w)
+				w = parent.right
+			}
+			w.red, parent.red, w.right.red = parent.red, false, false
+			r.rotateLeft(parent)
+		} else {
+			w := parent.left
+			if w.isRed() {
+				w.red, parent.red = false, true
+				r.rotateRight(parent)
+				w = parent.left
+			}
+			if !w.left.isRed() && !w.right.isRed() {
+				w.red = true
+				x, parent = parent, parent.parent
+				continue
+			}
+			if !w.left.isRed() {
+				w.right.red, w.red = false, true
+				r.rotateLeft(w)
+				w = parent.left
+			}
+			w.red, parent.red, w.left.red = parent.red, false, false
+			r.rotateRight(parent)
+		}
+		x = r.tree
+	}
+	if x != nil {
+		x.red = false
+	}
+}
+
+// 用以 v 为根的子树替换以 u 为根的子树
+func (r *RBTree[K, V]) transplant(u, v *rbNode[K, V]) {
+	if u.parent == nil {
+		r.tree = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+// 左旋，x 的右子节点取代 x 的位置
+func (r *RBTree[K, V]) rotateLeft(x *rbNode[K, V]) {
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	r.transplant(x, y)
+	y.left = x
+	x.parent = y
+}
+
+// 右旋，x 的左子节点取代 x 的位置
+func (r *RBTree[K, V]) rotateRight(x *rbNode[K, V]) {
+	y := x.left
+	x.left = y.right
+	if y.right != nil {
+		y.right.parent = x
+	}
+	r.transplant(x, y)
+	y.right = x
+	x.parent = y
+}
+
+func (t *rbNode[K, V]) minNode() *rbNode[K, V] {
+	if t == nil {
+		return nil
+	}
+	for t.left != nil {
+		t = t.left
+	}
+	return t
+}
+
+func (t *rbNode[K, V]) isRed() bool {
+	return t != nil && t.red
+}
@@ -0,0 +1,121 @@
+package collections
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRBTree(t *testing.T) {
+	tree := NewRBTree[int, struct{}]()
+	for i := 0; i < maxNum; i++ {
+		tree.Insert(i)
+		tree.Insert(2*maxNum - 1 - i)
+	}
+	assert.NoError(t, tree.Validate())
+
+	// 分别删除叶子节点、只有一个子节点的节点、有两个子节点的节点以及根节点，每次删除后检查约束
+	for i := 0; i < 2*maxNum; i += 3 {
+		assert.True(t, tree.Delete(i))
+		assert.NoError(t, tree.Validate())
+	}
+	assert.False(t, tree.Delete(0))
+	for tree.Len() > 0 {
+		root := tree.tree.key
+		assert.True(t, tree.Delete(root))
+		assert.False(t, tree.Contains(root))
+		assert.NoError(t, tree.Validate())
+	}
+	assert.Nil(t, tree.tree)
+}
+
+func TestRBTreeEmpty(t *testing.T) {
+	tree := NewRBTreeFunc[string, int](strings.Compare)
+	assert.False(t, tree.Delete("a"))
+	_, ok := tree.GetMinValue()
+	assert.False(t, ok)
+	_, ok = tree.GetMaxValue()
+	assert.False(t, ok)
+	assert.Equal(t, 0, len(tree.AllValues()))
+
+	tree.Put("a", 1)
+	tree.Put("a", 2)
+	assert.Equal(t, 1, tree.Len())
+	v, ok := tree.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.True(t, tree.Delete("a"))
+	assert.Nil(t, tree.tree)
+	assert.Equal(t, 0, tree.Len())
+}
+
+func TestRBTreeRandom(t *testing.T) {
+	tree := NewRBTree[int, int]()
+	oracle := make(map[int]int)
+	for i := 0; i < nums; i++ {
+		k := rand.Intn(nums / 2)
+		if rand.Intn(3) == 0 {
+			_, ok := oracle[k]
+			assert.Equal(t, ok, tree.Delete(k))
+			delete(oracle, k)
+		} else {
+			tree.Put(k, i)
+			oracle[k] = i
+		}
+		if err := tree.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.Equal(t, len(oracle), tree.Len())
+	for k, v := range oracle {
+		got, ok := tree.Get(k)
+		assert.True(t, ok)
+		assert.Equal(t, v, got)
+	}
+	assert.True(t, assertSort(tree.AllValues()))
+	assert.Equal(t, len(oracle), len(tree.Values()))
+}
+
+func TestRBTreeIterate(t *testing.T) {
+	tree := NewRBTree[int, int]()
+	for i := 0; i < maxNum; i++ {
+		tree.Put(i, i*i)
+	}
+	keys := make([]int, 0)
+	tree.Range(10, 20, IncludeLo, func(k, v int) bool {
+		assert.Equal(t, k*k, v)
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, []int{10, 11, 12, 13, 14, 15, 16, 17, 18, 19}, keys)
+
+	i := maxNum - 1
+	for k := range tree.Descend() {
+		assert.Equal(t, i, k)
+		i--
+	}
+	assert.Equal(t, -1, i)
+	for k := range tree.Ascend() {
+		if k == 5 {
+			break
+		}
+		i++
+	}
+	assert.Equal(t, 4, i)
+}
+
+func genRB(n int) *RBTree[int, struct{}] {
+	tree := NewRBTree[int, struct{}]()
+	for i := 0; i < n; i++ {
+		tree.Insert(rand.Int())
+	}
+	return tree
+}
+
+func BenchmarkRBInsert10e4(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		genRB(10e4)
+	}
+}
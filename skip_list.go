@@ -0,0 +1,301 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+)
+
+const (
+	skipListMaxLevel = 32 // 最大层数，足够容纳 4^32 个元素
+	skipListP        = 4  // 每个节点以 1/skipListP 的概率晋升到上一层
+)
+
+type skipListLink[K, V any] struct {
+	node *skipListNode[K, V]
+	span int // 从当前节点到 node 在第 0 层跨越的节点数，用于计算排名
+}
+
+type skipListNode[K, V any] struct {
+	key   K
+	value V
+	prev  *skipListNode[K, V] // 第 0 层的前驱节点，用于降序遍历
+	next  []skipListLink[K, V]
+}
+
+// SkipList 跳表，以随机层数代替旋转维持平衡，期望时间复杂度 O(logn)
+type SkipList[K, V any] struct {
+	head  *skipListNode[K, V]
+	tail  *skipListNode[K, V]
+	level int
+	n     int
+	cmp   func(a, b K) int
+}
+
+// 生成跳表，键类型需满足 cmp.Ordered
+func NewSkipList[K cmp.Ordered, V any]() *SkipList[K, V] {
+	return NewSkipListFunc[K, V](cmp.Compare[K])
+}
+
+// 使用自定义比较函数生成跳表
+func NewSkipListFunc[K, V any](cmp func(a, b K) int) *SkipList[K, V] {
+	return &SkipList[K, V]{
+		head:  &skipListNode[K, V]{next: make([]skipListLink[K, V], skipListMaxLevel)},
+		level: 1,
+		cmp:   cmp,
+	}
+}
+
+// 随机生成新节点的层数
+func skipListLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Uint32()%skipListP == 0 {
+		level++
+	}
+	return level
+}
+
+// 插入或更新键值对
+func (s *SkipList[K, V]) Put(k K, v V) {
+	var update [skipListMaxLevel]*skipListNode[K, V]
+	var rank [skipListMaxLevel]int
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		// rank[i] 记录 update[i] 在第 0 层的位置
+		if i < s.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i].node != nil && s.cmp(x.next[i].node.key, k) < 0 {
+			rank[i] += x.next[i].span
+			x = x.next[i].node
+		}
+		update[i] = x
+	}
+	if x = x.next[0].node; x != nil && s.cmp(x.key, k) == 0 {
+		x.value = v
+		return
+	}
+
+	level := skipListLevel()
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			update[i] = s.head
+			s.head.next[i].span = s.n
+		}
+		s.level = level
+	}
+	x = &skipListNode[K, V]{key: k, value: v, next: make([]skipListLink[K, V], level)}
+	for i := 0; i < level; i++ {
+		x.next[i].node = update[i].next[i].node
+		update[i].next[i].node = x
+		// 新节点将原来的跨度一分为二
+		x.next[i].span = update[i].next[i].span - (rank[0] - rank[i])
+		update[i].next[i].span = rank[0] - rank[i] + 1
+	}
+	// 更高层的链接跨过了新节点
+	for i := level; i < s.level; i++ {
+		update[i].next[i].span++
+	}
+	if update[0] != s.head {
+		x.prev = update[0]
+	}
+	if x.next[0].node != nil {
+		x.next[0].node.prev = x
+	} else {
+		s.tail = x
+	}
+	s.n++
+}
+
+// 获取键对应的值
+func (s *SkipList[K, V]) Get(k K) (V, bool) {
+	if x := s.search(k); x != nil {
+		return x.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// 判断键是否存在
+func (s *SkipList[K, V]) Contains(k K) bool {
+	return s.search(k) != nil
+}
+
+// 插入节点，值为 V 的零值
+func (s *SkipList[K, V]) Insert(k K) {
+	var zero V
+	s.Put(k, zero)
+}
+
+// 搜索节点
+func (s *SkipList[K, V]) Search(k K) bool {
+	return s.Contains(k)
+}
+
+// 删除节点
+func (s *SkipList[K, V]) Delete(k K) bool {
+	var update [skipListMaxLevel]*skipListNode[K, V]
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && s.cmp(x.next[i].node.key, k) < 0 {
+			x = x.next[i].node
+		}
+		update[i] = x
+	}
+	x = x.next[0].node
+	if x == nil || s.cmp(x.key, k) != 0 {
+		return false
+	}
+	for i := 0; i < s.level; i++ {
+		if update[i].next[i].node == x {
+			update[i].next[i].span += x.next[i].span - 1
+			update[i].next[i].node = x.next[i].node
+		} else {
+			update[i].next[i].span--
+		}
+	}
+	if x.next[0].node != nil {
+		x.next[0].node.prev = x.prev
+	} else {
+		s.tail = x.prev
+	}
+	for s.level > 1 && s.head.next[s.level-1].node == nil {
+		s.level--
+	}
+	s.n--
+	return true
+}
+
+// 获取最小的键，空表返回 false
+func (s *SkipList[K, V]) GetMinValue() (K, bool) {
+	if x := s.head.next[0].node; x != nil {
+		return x.key, true
+	}
+	var zero K
+	return zero, false
+}
+
+// 获取最大的键，空表返回 false
+func (s *SkipList[K, V]) GetMaxValue() (K, bool) {
+	if s.tail != nil {
+		return s.tail.key, true
+	}
+	var zero K
+	return zero, false
+}
+
+// 返回排序后所有键
+func (s *SkipList[K, V]) AllValues() []K {
+	keys := make([]K, 0, s.n)
+	for x := s.head.next[0].node; x != nil; x = x.next[0].node {
+		keys = append(keys, x.key)
+	}
+	return keys
+}
+
+// 按键的顺序返回所有值
+func (s *SkipList[K, V]) Values() []V {
+	values := make([]V, 0, s.n)
+	for x := s.head.next[0].node; x != nil; x = x.next[0].node {
+		values = append(values, x.value)
+	}
+	return values
+}
+
+// 键值对数量
+func (s *SkipList[K, V]) Len() int {
+	return s.n
+}
+
+// 返回小于 k 的键的数量
+func (s *SkipList[K, V]) Rank(k K) int {
+	rank := 0
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && s.cmp(x.next[i].node.key, k) < 0 {
+			rank += x.next[i].span
+			x = x.next[i].node
+		}
+	}
+	return rank
+}
+
+// 返回第 i 小的键值对，i 从 0 开始
+func (s *SkipList[K, V]) Select(i int) (K, V, bool) {
+	if i < 0 || i >= s.n {
+		var k K
+		var v V
+		return k, v, false
+	}
+	// 第 i 小的节点位于第 0 层的第 i+1 个位置
+	traversed := 0
+	x := s.head
+	for l := s.level - 1; l >= 0; l-- {
+		for x.next[l].node != nil && traversed+x.next[l].span <= i+1 {
+			traversed += x.next[l].span
+			x = x.next[l].node
+		}
+		if traversed == i+1 {
+			break
+		}
+	}
+	return x.key, x.value, true
+}
+
+// 按键升序遍历 [lo, hi] 区间内的键值对，bound 决定是否包含边界，fn 返回 false 时停止遍历
+func (s *SkipList[K, V]) Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool) {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil {
+			cmp := s.cmp(x.next[i].node.key, lo)
+			if cmp > 0 || (cmp == 0 && bound&IncludeLo != 0) {
+				break
+			}
+			x = x.next[i].node
+		}
+	}
+	for x = x.next[0].node; x != nil; x = x.next[0].node {
+		cmp := s.cmp(x.key, hi)
+		if cmp > 0 || (cmp == 0 && bound&IncludeHi == 0) {
+			return
+		}
+		if !fn(x.key, x.value) {
+			return
+		}
+	}
+}
+
+// 返回按键升序遍历的迭代器
+func (s *SkipList[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.head.next[0].node; x != nil; x = x.next[0].node {
+			if !yield(x.key, x.value) {
+				return
+			}
+		}
+	}
+}
+
+// 返回按键降序遍历的迭代器
+func (s *SkipList[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.tail; x != nil; x = x.prev {
+			if !yield(x.key, x.value) {
+				return
+			}
+		}
+	}
+}
+
+func (s *SkipList[K, V]) search(k K) *skipListNode[K, V] {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && s.cmp(x.next[i].node.key, k) < 0 {
+			x = x.next[i].node
+		}
+	}
+	if x = x.next[0].node; x != nil && s.cmp(x.key, k) == 0 {
+		return x
+	}
+	return nil
+}
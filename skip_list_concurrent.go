@@ -0,0 +1,284 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+type concurrentSkipListNode[K, V any] struct {
+	key         K
+	value       atomic.Pointer[V]
+	next        []atomic.Pointer[concurrentSkipListNode[K, V]]
+	mut         sync.Mutex
+	marked      atomic.Bool // 已被逻辑删除
+	fullyLinked atomic.Bool // 所有层都已链接完成
+}
+
+// ConcurrentSkipList 并发安全的跳表
+// 读操作不加锁也不会阻塞，写操作只锁住修改位置的前驱节点，不同位置的写操作可以并行
+type ConcurrentSkipList[K, V any] struct {
+	head *concurrentSkipListNode[K, V]
+	n    atomic.Int64
+	cmp  func(a, b K) int
+}
+
+// 生成并发跳表，键类型需满足 cmp.Ordered
+func NewConcurrentSkipList[K cmp.Ordered, V any]() *ConcurrentSkipList[K, V] {
+	return NewConcurrentSkipListFunc[K, V](cmp.Compare[K])
+}
+
+// 使用自定义比较函数生成并发跳表
+func NewConcurrentSkipListFunc[K, V any](cmp func(a, b K) int) *ConcurrentSkipList[K, V] {
+	head := &concurrentSkipListNode[K, V]{next: make([]atomic.Pointer[concurrentSkipListNode[K, V]], skipListMaxLevel)}
+	head.fullyLinked.Store(true)
+	return &ConcurrentSkipList[K, V]{head: head, cmp: cmp}
+}
+
+// 插入或更新键值对
+func (s *ConcurrentSkipList[K, V]) Put(k K, v V) {
+	var preds, succs [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	level := skipListLevel()
+	for {
+		if found := s.find(k, &preds, &succs); found >= 0 {
+			node := succs[found]
+			if !node.marked.Load() {
+				// 节点正在被其他写操作插入，等待链接完成后更新值
+				for !node.fullyLinked.Load() {
+					runtime.Gosched()
+				}
+				node.value.Store(&v)
+				return
+			}
+			// 节点正在被删除，重试
+			continue
+		}
+
+		// 自底向上锁住各层的前驱节点，并确认前驱和后继没有变化
+		highest := -1
+		valid := true
+		for i := 0; valid && i < level; i++ {
+			pred, succ := preds[i], succs[i]
+			if i == 0 || pred != preds[i-1] {
+				pred.mut.Lock()
+			}
+			highest = i
+			valid = !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.next[i].Load() == succ
+		}
+		if !valid {
+			unlockPreds(&preds, highest)
+			continue
+		}
+
+		node := &concurrentSkipListNode[K, V]{key: k, next: make([]atomic.Pointer[concurrentSkipListNode[K, V]], level)}
+		node.value.Store(&v)
+		for i := 0; i < level; i++ {
+			node.next[i].Store(succs[i])
+		}
+		for i := 0; i < level; i++ {
+			preds[i].next[i].Store(node)
+		}
+		node.fullyLinked.Store(true)
+		unlockPreds(&preds, highest)
+		s.n.Add(1)
+		return
+	}
+}
+
+// 获取键对应的值
+func (s *ConcurrentSkipList[K, V]) Get(k K) (V, bool) {
+	pred := s.head
+	for i := skipListMaxLevel - 1; i >= 0; i-- {
+		curr := pred.next[i].Load()
+		for curr != nil && s.cmp(curr.key, k) < 0 {
+			pred = curr
+			curr = pred.next[i].Load()
+		}
+		if curr != nil && s.cmp(curr.key, k) == 0 {
+			if curr.fullyLinked.Load() && !curr.marked.Load() {
+				return *curr.value.Load(), true
+			}
+			break
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// 判断键是否存在
+func (s *ConcurrentSkipList[K, V]) Contains(k K) bool {
+	_, ok := s.Get(k)
+	return ok
+}
+
+// 删除节点
+func (s *ConcurrentSkipList[K, V]) Delete(k K) bool {
+	var preds, succs [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	var victim *concurrentSkipListNode[K, V]
+	marked := false
+	for {
+		found := s.find(k, &preds, &succs)
+		if !marked {
+			// 只删除已链接完成、未被删除的节点，且需在节点的最高层找到它
+			if found < 0 {
+				return false
+			}
+			victim = succs[found]
+			if !victim.fullyLinked.Load() || victim.marked.Load() || len(victim.next)-1 != found {
+				return false
+			}
+			victim.mut.Lock()
+			if victim.marked.Load() {
+				victim.mut.Unlock()
+				return false
+			}
+			// 先标记为逻辑删除，之后的读操作都视为不存在
+			victim.marked.Store(true)
+			marked = true
+		}
+
+		highest := -1
+		valid := true
+		for i := 0; valid && i < len(victim.next); i++ {
+			pred := preds[i]
+			if i == 0 || pred != preds[i-1] {
+				pred.mut.Lock()
+			}
+			highest = i
+			valid = !pred.marked.Load() && pred.next[i].Load() == victim
+		}
+		if !valid {
+			unlockPreds(&preds, highest)
+			continue
+		}
+		// 自顶向下摘除节点
+		for i := len(victim.next) - 1; i >= 0; i-- {
+			preds[i].next[i].Store(victim.next[i].Load())
+		}
+		victim.mut.Unlock()
+		unlockPreds(&preds, highest)
+		s.n.Add(-1)
+		return true
+	}
+}
+
+// 键值对数量
+func (s *ConcurrentSkipList[K, V]) Len() int {
+	return int(s.n.Load())
+}
+
+// 按键升序遍历 [lo, hi] 区间内的键值对，bound 决定是否包含边界，fn 返回 false 时停止遍历
+// 遍历不加锁，期间其他 goroutine 的修改可能可见也可能不可见
+func (s *ConcurrentSkipList[K, V]) Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool) {
+	pred := s.head
+	for i := skipListMaxLevel - 1; i >= 0; i-- {
+		for curr := pred.next[i].Load(); curr != nil; curr = pred.next[i].Load() {
+			cmp := s.cmp(curr.key, lo)
+			if cmp > 0 || (cmp == 0 && bound&IncludeLo != 0) {
+				break
+			}
+			pred = curr
+		}
+	}
+	for x := pred.next[0].Load(); x != nil; x = x.next[0].Load() {
+		cmp := s.cmp(x.key, hi)
+		if cmp > 0 || (cmp == 0 && bound&IncludeHi == 0) {
+			return
+		}
+		if x.fullyLinked.Load() && !x.marked.Load() && !fn(x.key, *x.value.Load()) {
+			return
+		}
+	}
+}
+
+// 返回按键升序遍历的迭代器，遍历不加锁
+func (s *ConcurrentSkipList[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.head.next[0].Load(); x != nil; x = x.next[0].Load() {
+			if x.fullyLinked.Load() && !x.marked.Load() && !yield(x.key, *x.value.Load()) {
+				return
+			}
+		}
+	}
+}
+
+// 返回按键降序遍历的迭代器，遍历不加锁
+// 跳表只有后继指针，每一步都从头查找当前键的前驱，遍历 n 个节点的时间复杂度为 O(nlogn)
+func (s *ConcurrentSkipList[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := s.last(nil); x != nil; x = s.last(&x.key) {
+			if !yield(x.key, *x.value.Load()) {
+				return
+			}
+		}
+	}
+}
+
+// 获取最小键，空表返回 false
+func (s *ConcurrentSkipList[K, V]) GetMinValue() (K, bool) {
+	for x := s.head.next[0].Load(); x != nil; x = x.next[0].Load() {
+		if x.fullyLinked.Load() && !x.marked.Load() {
+			return x.key, true
+		}
+	}
+	var zero K
+	return zero, false
+}
+
+// 获取最大键，空表返回 false
+func (s *ConcurrentSkipList[K, V]) GetMaxValue() (K, bool) {
+	if x := s.last(nil); x != nil {
+		return x.key, true
+	}
+	var zero K
+	return zero, false
+}
+
+// 查找键小于 *k 的最后一个有效节点，k 为 nil 时查找整个跳表的最后一个有效节点，不存在时返回 nil
+func (s *ConcurrentSkipList[K, V]) last(k *K) *concurrentSkipListNode[K, V] {
+	for {
+		pred := s.head
+		for i := skipListMaxLevel - 1; i >= 0; i-- {
+			for curr := pred.next[i].Load(); curr != nil && (k == nil || s.cmp(curr.key, *k) < 0); curr = pred.next[i].Load() {
+				pred = curr
+			}
+		}
+		if pred == s.head {
+			return nil
+		}
+		if pred.fullyLinked.Load() && !pred.marked.Load() {
+			return pred
+		}
+		// 找到的节点正在插入或已被删除，继续查找它的前驱
+		k = &pred.key
+	}
+}
+
+// 查找各层中小于 k 的最后一个节点及其后继，返回找到 k 的最高层，未找到返回 -1
+func (s *ConcurrentSkipList[K, V]) find(k K, preds, succs *[skipListMaxLevel]*concurrentSkipListNode[K, V]) int {
+	found := -1
+	pred := s.head
+	for i := skipListMaxLevel - 1; i >= 0; i-- {
+		curr := pred.next[i].Load()
+		for curr != nil && s.cmp(curr.key, k) < 0 {
+			pred = curr
+			curr = pred.next[i].Load()
+		}
+		if found < 0 && curr != nil && s.cmp(curr.key, k) == 0 {
+			found = i
+		}
+		preds[i], succs[i] = pred, curr
+	}
+	return found
+}
+
+// 释放第 0 层到第 highest 层的前驱节点上的锁，相邻层相同的前驱只释放一次
+func unlockPreds[K, V any](preds *[skipListMaxLevel]*concurrentSkipListNode[K, V], highest int) {
+	for i := 0; i <= highest; i++ {
+		if i == 0 || preds[i] != preds[i-1] {
+			preds[i].mut.Unlock()
+		}
+	}
+}
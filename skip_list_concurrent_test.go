@@ -0,0 +1,167 @@
+package collections
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentSkipList(t *testing.T) {
+	list := NewConcurrentSkipList[int, int]()
+	assert.False(t, list.Delete(0))
+	_, ok := list.Get(0)
+	assert.False(t, ok)
+
+	list.Put(1, 1)
+	list.Put(1, 2)
+	v, ok := list.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 1, list.Len())
+	assert.True(t, list.Delete(1))
+	assert.False(t, list.Contains(1))
+	assert.Equal(t, 0, list.Len())
+}
+
+func TestConcurrentSkipListDescend(t *testing.T) {
+	list := NewConcurrentSkipList[int, int]()
+	_, ok := list.GetMinValue()
+	assert.False(t, ok)
+	_, ok = list.GetMaxValue()
+	assert.False(t, ok)
+
+	for _, k := range rand.Perm(maxNum) {
+		list.Put(k, -k)
+	}
+	for k := 1; k < maxNum; k += 2 {
+		list.Delete(k)
+	}
+	minKey, ok := list.GetMinValue()
+	assert.True(t, ok)
+	assert.Equal(t, 0, minKey)
+	maxKey, ok := list.GetMaxValue()
+	assert.True(t, ok)
+	assert.Equal(t, maxNum-2, maxKey)
+
+	want := maxNum - 2
+	for k, v := range list.Descend() {
+		assert.Equal(t, want, k)
+		assert.Equal(t, -want, v)
+		want -= 2
+	}
+	assert.Equal(t, -2, want)
+	for k := range list.Descend() {
+		if k == maxNum-6 {
+			break
+		}
+		want++
+	}
+	assert.Equal(t, 0, want)
+}
+
+func TestConcurrentSkipListDescendParallel(t *testing.T) {
+	list := NewConcurrentSkipList[int, struct{}]()
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			k := i % maxNum
+			list.Put(k, struct{}{})
+			list.Delete((k * 7) % maxNum)
+		}
+	}()
+	// 并发修改时降序遍历得到的键仍然严格递减
+	for i := 0; i < 100; i++ {
+		prev := maxNum
+		for k := range list.Descend() {
+			assert.Less(t, k, prev)
+			prev = k
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestConcurrentSkipListParallel(t *testing.T) {
+	list := NewConcurrentSkipList[int, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		// 写者：插入自己负责的键，再删除其中的奇数
+		go func(g int) {
+			defer wg.Done()
+			for i := g; i < 4*nums; i += 4 {
+				list.Put(i, i)
+			}
+			for i := g; i < 4*nums; i += 4 {
+				if i%2 == 1 {
+					assert.True(t, list.Delete(i))
+				}
+			}
+		}(g)
+		// 读者：读到的值总是与键一致，遍历结果总是有序
+		go func() {
+			defer wg.Done()
+			for i := 0; i < nums; i++ {
+				if v, ok := list.Get(i); ok {
+					assert.Equal(t, i, v)
+				}
+			}
+			prev := -1
+			for k, v := range list.Ascend() {
+				assert.Equal(t, k, v)
+				assert.Greater(t, k, prev)
+				prev = k
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 2*nums, list.Len())
+	i := 0
+	for k := range list.Ascend() {
+		assert.Equal(t, i, k)
+		i += 2
+	}
+	assert.Equal(t, 4*nums, i)
+
+	keys := make([]int, 0)
+	list.Range(10, 20, IncludeBoth, func(k, v int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, []int{10, 12, 14, 16, 18, 20}, keys)
+}
+
+func TestConcurrentSkipListContended(t *testing.T) {
+	// 多个 goroutine 同时插入和删除同一组键，每个键只会被成功删除一次
+	list := NewConcurrentSkipList[int, struct{}]()
+	for i := 0; i < nums; i++ {
+		list.Put(i, struct{}{})
+	}
+	deleted := make([]int, 4)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < nums; i++ {
+				if list.Delete(i) {
+					deleted[g]++
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, nums, deleted[0]+deleted[1]+deleted[2]+deleted[3])
+	assert.Equal(t, 0, list.Len())
+}
@@ -0,0 +1,115 @@
+package collections
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipList(t *testing.T) {
+	list := NewSkipList[int, struct{}]()
+	for i := 0; i < maxNum; i++ {
+		list.Insert(i)
+		list.Insert(maxNum + i)
+	}
+	assert.Equal(t, len(list.AllValues()), maxNum*2)
+	assert.True(t, assertSort(list.AllValues()))
+	maxKey, ok := list.GetMaxValue()
+	assert.True(t, ok)
+	assert.Equal(t, maxKey, 2*maxNum-1)
+	minKey, ok := list.GetMinValue()
+	assert.True(t, ok)
+	assert.Equal(t, minKey, 0)
+	assert.True(t, list.Search(50))
+	assert.False(t, list.Search(-10))
+	assert.False(t, list.Delete(-10))
+	assert.True(t, list.Delete(10))
+	assert.Equal(t, len(list.AllValues()), maxNum*2-1)
+}
+
+func TestSkipListEmpty(t *testing.T) {
+	list := NewSkipListFunc[string, int](strings.Compare)
+	assert.False(t, list.Delete("a"))
+	_, ok := list.GetMinValue()
+	assert.False(t, ok)
+	_, ok = list.GetMaxValue()
+	assert.False(t, ok)
+	_, _, ok = list.Select(0)
+	assert.False(t, ok)
+	assert.Equal(t, 0, list.Rank("a"))
+
+	list.Put("a", 1)
+	list.Put("a", 2)
+	assert.Equal(t, 1, list.Len())
+	v, ok := list.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.True(t, list.Delete("a"))
+	assert.Equal(t, 0, list.Len())
+	assert.Nil(t, list.tail)
+	assert.Equal(t, 1, list.level)
+}
+
+func TestSkipListRandom(t *testing.T) {
+	list := NewSkipList[int, int]()
+	oracle := make([]int, 0)
+	for i := 0; i < 4*nums; i++ {
+		k := rand.Intn(nums)
+		j := sort.SearchInts(oracle, k)
+		found := j < len(oracle) && oracle[j] == k
+		if rand.Intn(3) == 0 {
+			assert.Equal(t, found, list.Delete(k))
+			if found {
+				oracle = append(oracle[:j], oracle[j+1:]...)
+			}
+		} else {
+			list.Put(k, -k)
+			if !found {
+				oracle = append(oracle[:j], append([]int{k}, oracle[j:]...)...)
+			}
+		}
+	}
+	assert.Equal(t, oracle, list.AllValues())
+	assert.Equal(t, len(oracle), list.Len())
+
+	for i, k := range oracle {
+		assert.Equal(t, i, list.Rank(k))
+		assert.Equal(t, i+1, list.Rank(k+1))
+		sk, sv, ok := list.Select(i)
+		assert.True(t, ok)
+		assert.Equal(t, k, sk)
+		assert.Equal(t, -k, sv)
+	}
+
+	i := len(oracle) - 1
+	for k := range list.Descend() {
+		assert.Equal(t, oracle[i], k)
+		i--
+	}
+	assert.Equal(t, -1, i)
+}
+
+func TestSkipListRange(t *testing.T) {
+	list := NewSkipList[int, int]()
+	for i := 0; i < maxNum; i += 2 {
+		list.Put(i, i*i)
+	}
+	for _, bound := range []RangeBound{0, IncludeLo, IncludeHi, IncludeBoth} {
+		want := make([]int, 0)
+		for i := 0; i < maxNum; i += 2 {
+			if (i > 10 || (i == 10 && bound&IncludeLo != 0)) && (i < 30 || (i == 30 && bound&IncludeHi != 0)) {
+				want = append(want, i)
+			}
+		}
+		got := make([]int, 0)
+		list.Range(10, 30, bound, func(k, v int) bool {
+			assert.Equal(t, k*k, v)
+			got = append(got, k)
+			return true
+		})
+		assert.Equal(t, want, got)
+	}
+}
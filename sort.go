@@ -254,3 +254,42 @@ func mergeArray(items []int, first, mid, last int, res []int) {
 		items[first+i] = res[i]
 	}
 }
+
+// 使用比较函数的归并排序，支持任意类型，稳定
+// cmp(a, b) 在 a < b 时返回负数，a == b 时返回 0，a > b 时返回正数
+func MergeSortFunc[T any](items []T, cmp func(a, b T) int) {
+	length := len(items)
+	if length < 2 {
+		return
+	}
+	res := make([]T, length)
+	mergeSortFunc(items, 0, length-1, res, cmp)
+}
+
+// 与 mergeSort 相同，使用比较函数比较元素
+func mergeSortFunc[T any](items []T, first, last int, res []T, cmp func(a, b T) int) {
+	if first < last {
+		mid := (first + last) / 2
+		mergeSortFunc(items, first, mid, res, cmp)
+		mergeSortFunc(items, mid+1, last, res, cmp)
+		mergeArrayFunc(items, first, mid, last, res, cmp)
+	}
+}
+
+// 与 mergeArray 相同，使用比较函数比较元素
+func mergeArrayFunc[T any](items []T, first, mid, last int, res []T, cmp func(a, b T) int) {
+	i, j, k := first, mid+1, 0
+	for i <= mid && j <= last {
+		if cmp(items[i], items[j]) <= 0 {
+			res[k] = items[i]
+			i++
+		} else {
+			res[k] = items[j]
+			j++
+		}
+		k++
+	}
+	k += copy(res[k:], items[i:mid+1])
+	k += copy(res[k:], items[j:last+1])
+	copy(items[first:], res[:k])
+}
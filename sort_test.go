@@ -1,6 +1,7 @@
 package collections
 
 import (
+	"cmp"
 	"math/rand"
 	"sort"
 	"testing"
@@ -133,6 +134,19 @@ func BenchmarkMergeSort(b *testing.B) {
 	}
 }
 
+func TestMergeSortFunc(t *testing.T) {
+	items := yieldRandomArray(maxCnt)
+	MergeSortFunc(items, func(a, b int) int { return cmp.Compare(b, a) })
+	for i := 0; i < len(items)-1; i++ {
+		assert.True(t, items[i] >= items[i+1])
+	}
+
+	type pair struct{ k, i int }
+	pairs := []pair{{2, 0}, {1, 1}, {2, 2}, {1, 3}}
+	MergeSortFunc(pairs, func(a, b pair) int { return a.k - b.k })
+	assert.Equal(t, []pair{{1, 1}, {1, 3}, {2, 0}, {2, 2}}, pairs)
+}
+
 func TestStdSortWithoutInterface(t *testing.T) {
 	items := yieldRandomArray(maxCnt)
 	StdSortWithoutInterface(items)
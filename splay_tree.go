@@ -0,0 +1,275 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+)
+
+type splayNode[K, V any] struct {
+	key   K
+	value V
+	left  *splayNode[K, V]
+	right *splayNode[K, V]
+}
+
+// SplayTree 伸展树，每次访问后将被访问的节点旋转到根节点
+// 单次操作最坏 O(n)，均摊 O(logn)，访问分布越集中，常用的键离根节点越近
+type SplayTree[K, V any] struct {
+	tree *splayNode[K, V]
+	n    int
+	cmp  func(a, b K) int
+}
+
+// 生成伸展树，键类型需满足 cmp.Ordered
+func NewSplayTree[K cmp.Ordered, V any]() *SplayTree[K, V] {
+	return NewSplayTreeFunc[K, V](cmp.Compare[K])
+}
+
+// 使用自定义比较函数生成伸展树
+func NewSplayTreeFunc[K, V any](cmp func(a, b K) int) *SplayTree[K, V] {
+	return &SplayTree[K, V]{cmp: cmp}
+}
+
+// 插入或更新键值对，插入的节点成为新的根节点
+func (s *SplayTree[K, V]) Put(k K, v V) {
+	if s.tree == nil {
+		s.tree = &splayNode[K, V]{key: k, value: v}
+		s.n++
+		return
+	}
+	t := s.splay(s.tree, k)
+	cmp := s.cmp(k, t.key)
+	if cmp == 0 {
+		t.value = v
+		s.tree = t
+		return
+	}
+	// 伸展后根节点是 k 的前驱或后继，以新节点为根拆开挂在两侧
+	node := &splayNode[K, V]{key: k, value: v}
+	if cmp < 0 {
+		node.left, node.right = t.left, t
+		t.left = nil
+	} else {
+		node.left, node.right = t, t.right
+		t.right = nil
+	}
+	s.tree = node
+	s.n++
+}
+
+// 获取键对应的值，找到的节点会被旋转到根节点
+func (s *SplayTree[K, V]) Get(k K) (V, bool) {
+	if s.tree != nil {
+		s.tree = s.splay(s.tree, k)
+		if s.cmp(k, s.tree.key) == 0 {
+			return s.tree.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// 判断键是否存在，找到的节点会被旋转到根节点
+func (s *SplayTree[K, V]) Contains(k K) bool {
+	_, ok := s.Get(k)
+	return ok
+}
+
+// 插入节点，值为 V 的零值
+func (s *SplayTree[K, V]) Insert(k K) {
+	var zero V
+	s.Put(k, zero)
+}
+
+// 搜索节点
+func (s *SplayTree[K, V]) Search(k K) bool {
+	return s.Contains(k)
+}
+
+// 删除节点
+func (s *SplayTree[K, V]) Delete(k K) bool {
+	if !s.Contains(k) {
+		return false
+	}
+	// 此时 k 位于根节点，左子树中的最大节点伸展到根后没有右子树，直接挂上原来的右子树
+	t := s.tree
+	if t.left == nil {
+		s.tree = t.right
+	} else {
+		s.tree = s.splay(t.left, k)
+		s.tree.right = t.right
+	}
+	s.n--
+	return true
+}
+
+// 获取所有节点中的最大键，空树返回 false
+func (s *SplayTree[K, V]) GetMaxValue() (K, bool) {
+	t := s.tree
+	if t == nil {
+		var zero K
+		return zero, false
+	}
+	for t.right != nil {
+		t = t.right
+	}
+	return t.key, true
+}
+
+// 获取所有节点中的最小键，空树返回 false
+func (s *SplayTree[K, V]) GetMinValue() (K, bool) {
+	t := s.tree
+	if t == nil {
+		var zero K
+		return zero, false
+	}
+	for t.left != nil {
+		t = t.left
+	}
+	return t.key, true
+}
+
+// 返回排序后所有键
+func (s *SplayTree[K, V]) AllValues() []K {
+	keys := make([]K, 0, s.n)
+	for k := range s.Ascend() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// 按键的顺序返回所有值
+func (s *SplayTree[K, V]) Values() []V {
+	values := make([]V, 0, s.n)
+	for _, v := range s.Ascend() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// 节点数量
+func (s *SplayTree[K, V]) Len() int {
+	return s.n
+}
+
+// 按键升序遍历 [lo, hi] 区间内的键值对，bound 决定是否包含边界，fn 返回 false 时停止遍历
+// 遍历不会调整树的结构
+func (s *SplayTree[K, V]) Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool) {
+	var stack []*splayNode[K, V]
+	for t := s.tree; t != nil; {
+		cmp := s.cmp(t.key, lo)
+		if cmp > 0 || (cmp == 0 && bound&IncludeLo != 0) {
+			stack = append(stack, t)
+			t = t.left
+		} else {
+			t = t.right
+		}
+	}
+	for len(stack) > 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		cmp := s.cmp(t.key, hi)
+		if cmp > 0 || (cmp == 0 && bound&IncludeHi == 0) {
+			return
+		}
+		if !fn(t.key, t.value) {
+			return
+		}
+		for t = t.right; t != nil; t = t.left {
+			stack = append(stack, t)
+		}
+	}
+}
+
+// 返回按键升序遍历的迭代器
+func (s *SplayTree[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*splayNode[K, V]
+		for t := s.tree; t != nil; t = t.left {
+			stack = append(stack, t)
+		}
+		for len(stack) > 0 {
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(t.key, t.value) {
+				return
+			}
+			for t = t.right; t != nil; t = t.left {
+				stack = append(stack, t)
+			}
+		}
+	}
+}
+
+// 返回按键降序遍历的迭代器
+func (s *SplayTree[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*splayNode[K, V]
+		for t := s.tree; t != nil; t = t.right {
+			stack = append(stack, t)
+		}
+		for len(stack) > 0 {
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(t.key, t.value) {
+				return
+			}
+			for t = t.left; t != nil; t = t.right {
+				stack = append(stack, t)
+			}
+		}
+	}
+}
+
+// 自顶向下伸展：沿查找路径将节点拆到左右两棵临时树中，最后以最接近 k 的节点为根重新组装
+// k 不存在时根节点为最后访问的节点，即 k 的前驱或后继
+func (s *SplayTree[K, V]) splay(t *splayNode[K, V], k K) *splayNode[K, V] {
+	var header splayNode[K, V]
+	// l 为左侧临时树中的最大节点，r 为右侧临时树中的最小节点
+	l, r := &header, &header
+	for {
+		cmp := s.cmp(k, t.key)
+		if cmp < 0 {
+			if t.left == nil {
+				break
+			}
+			if s.cmp(k, t.left.key) < 0 {
+				// 左左情况先右旋
+				node := t.left
+				t.left = node.right
+				node.right = t
+				t = node
+				if t.left == nil {
+					break
+				}
+			}
+			// 当前节点及其右子树都大于 k，挂到右侧临时树
+			r.left = t
+			r = t
+			t = t.left
+		} else if cmp > 0 {
+			if t.right == nil {
+				break
+			}
+			if s.cmp(k, t.right.key) > 0 {
+				// 右右情况先左旋
+				node := t.right
+				t.right = node.left
+				node.left = t
+				t = node
+				if t.right == nil {
+					break
+				}
+			}
+			// 当前节点及其左子树都小于 k，挂到左侧临时树
+			l.right = t
+			l = t
+			t = t.right
+		} else {
+			break
+		}
+	}
+	l.right, r.left = t.left, t.right
+	t.left, t.right = header.right, header.left
+	return t
+}
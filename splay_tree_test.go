@@ -0,0 +1,115 @@
+package collections
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplayTree(t *testing.T) {
+	tree := NewSplayTree[int, int]()
+	for i := 0; i < maxNum; i++ {
+		tree.Put(2*i, i)
+	}
+	// 新插入的节点成为根节点
+	assert.Equal(t, 2*maxNum-2, tree.tree.key)
+
+	// 访问过的节点被旋转到根节点
+	v, ok := tree.Get(50)
+	assert.True(t, ok)
+	assert.Equal(t, 25, v)
+	assert.Equal(t, 50, tree.tree.key)
+	assert.True(t, tree.Search(10))
+	assert.Equal(t, 10, tree.tree.key)
+	tree.Put(50, -1)
+	assert.Equal(t, 50, tree.tree.key)
+	tree.Put(71, 0)
+	assert.Equal(t, 71, tree.tree.key)
+
+	// 查找失败时最后访问的前驱或后继被旋转到根节点
+	assert.False(t, tree.Search(31))
+	assert.Contains(t, []int{30, 32}, tree.tree.key)
+
+	// 删除后左子树中的最大节点成为根节点
+	assert.True(t, tree.Delete(40))
+	assert.Equal(t, 38, tree.tree.key)
+	assert.Equal(t, maxNum, tree.Len())
+	assert.True(t, assertSort(tree.AllValues()))
+}
+
+func TestSplayTreeEmpty(t *testing.T) {
+	tree := NewSplayTreeFunc[string, int](strings.Compare)
+	assert.False(t, tree.Delete("a"))
+	_, ok := tree.GetMinValue()
+	assert.False(t, ok)
+	_, ok = tree.GetMaxValue()
+	assert.False(t, ok)
+
+	tree.Put("a", 1)
+	tree.Put("a", 2)
+	assert.Equal(t, 1, tree.Len())
+	v, ok := tree.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.True(t, tree.Delete("a"))
+	assert.Nil(t, tree.tree)
+}
+
+func TestSplayTreeRandom(t *testing.T) {
+	tree := NewSplayTree[int, int]()
+	oracle := make([]int, 0)
+	for i := 0; i < 4*nums; i++ {
+		k := rand.Intn(nums)
+		j := sort.SearchInts(oracle, k)
+		found := j < len(oracle) && oracle[j] == k
+		switch rand.Intn(3) {
+		case 0:
+			assert.Equal(t, found, tree.Delete(k))
+			if found {
+				oracle = append(oracle[:j], oracle[j+1:]...)
+			}
+		case 1:
+			v, ok := tree.Get(k)
+			assert.Equal(t, found, ok)
+			if ok {
+				assert.Equal(t, -k, v)
+			}
+		default:
+			tree.Put(k, -k)
+			if !found {
+				oracle = append(oracle[:j], append([]int{k}, oracle[j:]...)...)
+			}
+		}
+		assert.Equal(t, len(oracle), tree.Len())
+	}
+	assert.Equal(t, oracle, tree.AllValues())
+
+	got := make([]int, 0)
+	for k := range tree.Descend() {
+		got = append(got, k)
+	}
+	sort.Ints(got)
+	assert.Equal(t, oracle, got)
+
+	got = got[:0]
+	tree.Range(nums/4, nums/2, IncludeLo, func(k, v int) bool {
+		got = append(got, k)
+		return true
+	})
+	lo, hi := sort.SearchInts(oracle, nums/4), sort.SearchInts(oracle, nums/2)
+	assert.Equal(t, oracle[lo:hi], got)
+}
+
+func TestSplayTreeSequential(t *testing.T) {
+	// 顺序插入会得到一条链，遍历和伸展都不能依赖递归
+	tree := NewSplayTree[int, struct{}]()
+	for i := 0; i < 10*nums; i++ {
+		tree.Insert(i)
+	}
+	assert.True(t, tree.Search(0))
+	assert.True(t, assertSort(tree.AllValues()))
+	assert.Equal(t, 10*nums, tree.Len())
+}
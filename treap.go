@@ -0,0 +1,342 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+)
+
+type treapNode[K, V any] struct {
+	key   K
+	value V
+	prio  uint64 // 随机优先级，父节点的优先级不小于子节点
+	n     int    // 子树节点数
+	left  *treapNode[K, V]
+	right *treapNode[K, V]
+}
+
+// Treap 树堆，键满足二叉查找树的顺序，随机优先级满足堆的顺序，期望树高 O(logn)
+// 以 split 和 merge 为基本操作，支持按键切分与连接
+type Treap[K, V any] struct {
+	tree *treapNode[K, V]
+	cmp  func(a, b K) int
+}
+
+// 生成树堆，键类型需满足 cmp.Ordered
+func NewTreap[K cmp.Ordered, V any]() *Treap[K, V] {
+	return NewTreapFunc[K, V](cmp.Compare[K])
+}
+
+// 使用自定义比较函数生成树堆
+func NewTreapFunc[K, V any](cmp func(a, b K) int) *Treap[K, V] {
+	return &Treap[K, V]{cmp: cmp}
+}
+
+// 按 k 将树切分为两棵树，left 中的键都小于 k，right 中的键都大于等于 k
+// 切分后原树为空
+func (tr *Treap[K, V]) Split(k K) (left, right *Treap[K, V]) {
+	l, r := tr.split(tr.tree, k)
+	tr.tree = nil
+	return &Treap[K, V]{tree: l, cmp: tr.cmp}, &Treap[K, V]{tree: r, cmp: tr.cmp}
+}
+
+// 连接两棵树，要求 left 中的键都小于 right 中的键，否则 panic
+// 结果沿用 left 的比较函数，连接后 left 和 right 均为空
+func MergeTreap[K, V any](left, right *Treap[K, V]) *Treap[K, V] {
+	lmax, lok := left.GetMaxValue()
+	rmin, rok := right.GetMinValue()
+	if lok && rok && left.cmp(lmax, rmin) >= 0 {
+		panic("collections: keys of left tree must be less than keys of right tree")
+	}
+	res := &Treap[K, V]{tree: treapMerge(left.tree, right.tree), cmp: left.cmp}
+	left.tree, right.tree = nil, nil
+	return res
+}
+
+// 插入或更新键值对
+func (tr *Treap[K, V]) Put(k K, v V) {
+	if t := tr.search(k); t != nil {
+		t.value = v
+		return
+	}
+	tr.tree = tr.insert(tr.tree, &treapNode[K, V]{key: k, value: v, prio: rand.Uint64(), n: 1})
+}
+
+// 获取键对应的值
+func (tr *Treap[K, V]) Get(k K) (V, bool) {
+	if t := tr.search(k); t != nil {
+		return t.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// 判断键是否存在
+func (tr *Treap[K, V]) Contains(k K) bool {
+	return tr.search(k) != nil
+}
+
+// 插入节点，值为 V 的零值
+func (tr *Treap[K, V]) Insert(k K) {
+	var zero V
+	tr.Put(k, zero)
+}
+
+// 搜索节点
+func (tr *Treap[K, V]) Search(k K) bool {
+	return tr.Contains(k)
+}
+
+// 删除节点
+func (tr *Treap[K, V]) Delete(k K) bool {
+	if !tr.Contains(k) {
+		return false
+	}
+	tr.tree = tr.delete(tr.tree, k)
+	return true
+}
+
+// 获取所有节点中的最大键，空树返回 false
+func (tr *Treap[K, V]) GetMaxValue() (K, bool) {
+	t := tr.tree
+	if t == nil {
+		var zero K
+		return zero, false
+	}
+	for t.right != nil {
+		t = t.right
+	}
+	return t.key, true
+}
+
+// 获取所有节点中的最小键，空树返回 false
+func (tr *Treap[K, V]) GetMinValue() (K, bool) {
+	t := tr.tree
+	if t == nil {
+		var zero K
+		return zero, false
+	}
+	for t.left != nil {
+		t = t.left
+	}
+	return t.key, true
+}
+
+// 返回排序后所有键
+func (tr *Treap[K, V]) AllValues() []K {
+	keys := make([]K, 0, tr.Len())
+	for k := range tr.Ascend() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// 按键的顺序返回所有值
+func (tr *Treap[K, V]) Values() []V {
+	values := make([]V, 0, tr.Len())
+	for _, v := range tr.Ascend() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// 节点数量
+func (tr *Treap[K, V]) Len() int {
+	return tr.tree.size()
+}
+
+// 返回小于 k 的键的数量
+func (tr *Treap[K, V]) Rank(k K) int {
+	rank := 0
+	for t := tr.tree; t != nil; {
+		if tr.cmp(k, t.key) > 0 {
+			rank += t.left.size() + 1
+			t = t.right
+		} else {
+			t = t.left
+		}
+	}
+	return rank
+}
+
+// 返回第 i 小的键值对，i 从 0 开始
+func (tr *Treap[K, V]) Select(i int) (K, V, bool) {
+	t := tr.tree
+	if i < 0 || i >= t.size() {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for {
+		ls := t.left.size()
+		if i < ls {
+			t = t.left
+		} else if i > ls {
+			i -= ls + 1
+			t = t.right
+		} else {
+			return t.key, t.value, true
+		}
+	}
+}
+
+// 按键升序遍历 [lo, hi] 区间内的键值对，bound 决定是否包含边界，fn 返回 false 时停止遍历
+func (tr *Treap[K, V]) Range(lo, hi K, bound RangeBound, fn func(k K, v V) bool) {
+	var stack []*treapNode[K, V]
+	for t := tr.tree; t != nil; {
+		cmp := tr.cmp(t.key, lo)
+		if cmp > 0 || (cmp == 0 && bound&IncludeLo != 0) {
+			stack = append(stack, t)
+			t = t.left
+		} else {
+			t = t.right
+		}
+	}
+	for len(stack) > 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		cmp := tr.cmp(t.key, hi)
+		if cmp > 0 || (cmp == 0 && bound&IncludeHi == 0) {
+			return
+		}
+		if !fn(t.key, t.value) {
+			return
+		}
+		for t = t.right; t != nil; t = t.left {
+			stack = append(stack, t)
+		}
+	}
+}
+
+// 返回按键升序遍历的迭代器
+func (tr *Treap[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*treapNode[K, V]
+		for t := tr.tree; t != nil; t = t.left {
+			stack = append(stack, t)
+		}
+		for len(stack) > 0 {
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(t.key, t.value) {
+				return
+			}
+			for t = t.right; t != nil; t = t.left {
+				stack = append(stack, t)
+			}
+		}
+	}
+}
+
+// 返回按键降序遍历的迭代器
+func (tr *Treap[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var stack []*treapNode[K, V]
+		for t := tr.tree; t != nil; t = t.right {
+			stack = append(stack, t)
+		}
+		for len(stack) > 0 {
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(t.key, t.value) {
+				return
+			}
+			for t = t.left; t != nil; t = t.right {
+				stack = append(stack, t)
+			}
+		}
+	}
+}
+
+func (tr *Treap[K, V]) search(k K) *treapNode[K, V] {
+	t := tr.tree
+	for t != nil {
+		cmp := tr.cmp(k, t.key)
+		if cmp > 0 {
+			t = t.right
+		} else if cmp < 0 {
+			t = t.left
+		} else {
+			return t
+		}
+	}
+	return nil
+}
+
+// 插入键不存在的节点，沿查找路径下降到优先级比新节点低的位置，再将该子树按键切分挂到新节点两侧
+func (tr *Treap[K, V]) insert(t, node *treapNode[K, V]) *treapNode[K, V] {
+	if t == nil {
+		return node
+	}
+	if node.prio > t.prio {
+		node.left, node.right = tr.split(t, node.key)
+		node.update()
+		return node
+	}
+	if tr.cmp(node.key, t.key) < 0 {
+		t.left = tr.insert(t.left, node)
+	} else {
+		t.right = tr.insert(t.right, node)
+	}
+	t.update()
+	return t
+}
+
+// 删除存在的键，找到的节点由其左右子树合并后取代
+func (tr *Treap[K, V]) delete(t *treapNode[K, V], k K) *treapNode[K, V] {
+	cmp := tr.cmp(k, t.key)
+	if cmp == 0 {
+		return treapMerge(t.left, t.right)
+	}
+	if cmp < 0 {
+		t.left = tr.delete(t.left, k)
+	} else {
+		t.right = tr.delete(t.right, k)
+	}
+	t.update()
+	return t
+}
+
+// 按 k 切分子树，返回小于 k 的部分和大于等于 k 的部分
+func (tr *Treap[K, V]) split(t *treapNode[K, V], k K) (l, r *treapNode[K, V]) {
+	if t == nil {
+		return nil, nil
+	}
+	if tr.cmp(t.key, k) < 0 {
+		t.right, r = tr.split(t.right, k)
+		t.update()
+		return t, r
+	}
+	l, t.left = tr.split(t.left, k)
+	t.update()
+	return l, t
+}
+
+// 合并两棵子树，l 中的键都小于 r 中的键，优先级高的节点作为根
+func treapMerge[K, V any](l, r *treapNode[K, V]) *treapNode[K, V] {
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	if l.prio > r.prio {
+		l.right = treapMerge(l.right, r)
+		l.update()
+		return l
+	}
+	r.left = treapMerge(l, r.left)
+	r.update()
+	return r
+}
+
+func (t *treapNode[K, V]) size() int {
+	if t != nil {
+		return t.n
+	}
+	return 0
+}
+
+func (t *treapNode[K, V]) update() {
+	t.n = t.left.size() + t.right.size() + 1
+}
@@ -0,0 +1,130 @@
+package collections
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreapEmpty(t *testing.T) {
+	tree := NewTreapFunc[string, int](strings.Compare)
+	assert.False(t, tree.Delete("a"))
+	_, ok := tree.GetMinValue()
+	assert.False(t, ok)
+	_, _, ok = tree.Select(0)
+	assert.False(t, ok)
+
+	tree.Put("a", 1)
+	tree.Put("a", 2)
+	assert.Equal(t, 1, tree.Len())
+	v, ok := tree.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.True(t, tree.Delete("a"))
+	assert.Equal(t, 0, tree.Len())
+}
+
+// 检查键的顺序、优先级的堆序以及子树节点数
+func checkTreap(t *testing.T, tr *Treap[int, int], node *treapNode[int, int]) int {
+	if node == nil {
+		return 0
+	}
+	if node.left != nil {
+		assert.Less(t, node.left.key, node.key)
+		assert.LessOrEqual(t, node.left.prio, node.prio)
+	}
+	if node.right != nil {
+		assert.Greater(t, node.right.key, node.key)
+		assert.LessOrEqual(t, node.right.prio, node.prio)
+	}
+	n := checkTreap(t, tr, node.left) + checkTreap(t, tr, node.right) + 1
+	assert.Equal(t, n, node.n)
+	return n
+}
+
+func TestTreapRandom(t *testing.T) {
+	tree := NewTreap[int, int]()
+	oracle := make([]int, 0)
+	for i := 0; i < 4*nums; i++ {
+		k := rand.Intn(nums)
+		j := sort.SearchInts(oracle, k)
+		found := j < len(oracle) && oracle[j] == k
+		if rand.Intn(3) == 0 {
+			assert.Equal(t, found, tree.Delete(k))
+			if found {
+				oracle = append(oracle[:j], oracle[j+1:]...)
+			}
+		} else {
+			tree.Put(k, -k)
+			if !found {
+				oracle = append(oracle[:j], append([]int{k}, oracle[j:]...)...)
+			}
+		}
+	}
+	checkTreap(t, tree, tree.tree)
+	assert.Equal(t, oracle, tree.AllValues())
+	assert.Equal(t, len(oracle), tree.Len())
+	for i, k := range oracle {
+		assert.Equal(t, i, tree.Rank(k))
+		sk, sv, ok := tree.Select(i)
+		assert.True(t, ok)
+		assert.Equal(t, k, sk)
+		assert.Equal(t, -k, sv)
+	}
+
+	got := make([]int, 0)
+	tree.Range(nums/4, nums/2, IncludeBoth, func(k, v int) bool {
+		got = append(got, k)
+		return true
+	})
+	lo, hi := sort.SearchInts(oracle, nums/4), sort.SearchInts(oracle, nums/2+1)
+	assert.Equal(t, oracle[lo:hi], got)
+
+	got = got[:0]
+	for k := range tree.Descend() {
+		got = append(got, k)
+	}
+	sort.Ints(got)
+	assert.Equal(t, oracle, got)
+}
+
+func TestTreapSplitMerge(t *testing.T) {
+	tree := NewTreap[int, int]()
+	for i := 0; i < nums; i++ {
+		tree.Put(i, i)
+	}
+	left, right := tree.Split(nums / 3)
+	assert.Equal(t, 0, tree.Len())
+	assert.Equal(t, nums/3, left.Len())
+	assert.Equal(t, nums-nums/3, right.Len())
+	checkTreap(t, left, left.tree)
+	checkTreap(t, right, right.tree)
+	minKey, _ := right.GetMinValue()
+	assert.Equal(t, nums/3, minKey)
+
+	// 键的范围有重叠或相接时 panic，两棵树保持不变
+	assert.Panics(t, func() { MergeTreap(right, left) })
+	overlap := NewTreap[int, int]()
+	overlap.Put(nums/3-1, 0)
+	overlap.Put(nums, 0)
+	assert.Panics(t, func() { MergeTreap(left, overlap) })
+	assert.Panics(t, func() { MergeTreap(overlap, right) })
+	assert.Equal(t, nums/3, left.Len())
+	assert.Equal(t, 2, overlap.Len())
+
+	// 任意一侧为空时直接连接
+	empty, all := left.Split(-1)
+	assert.Equal(t, 0, empty.Len())
+	left = MergeTreap(empty, all)
+	assert.Equal(t, nums/3, left.Len())
+
+	merged := MergeTreap(left, right)
+	assert.Equal(t, 0, left.Len())
+	assert.Equal(t, 0, right.Len())
+	checkTreap(t, merged, merged.tree)
+	assert.Equal(t, nums, merged.Len())
+	assert.True(t, assertSort(merged.AllValues()))
+}